@@ -6,11 +6,35 @@ import (
 	"os"
 	"os/signal"
 	"ride-sharing-notification/config"
+	"ride-sharing-notification/internal/delivery/admin"
 	"ride-sharing-notification/internal/delivery/kafka"
 	"ride-sharing-notification/internal/delivery/rpc"
+	"ride-sharing-notification/internal/pkg/auth"
+	"ride-sharing-notification/internal/pkg/bounce"
+	"ride-sharing-notification/internal/pkg/devices"
 	"ride-sharing-notification/internal/pkg/email"
+	"ride-sharing-notification/internal/pkg/firebase"
+	"ride-sharing-notification/internal/pkg/inbox"
 	"ride-sharing-notification/internal/pkg/logging"
+	"ride-sharing-notification/internal/pkg/metrics"
+	"ride-sharing-notification/internal/pkg/notify"
+	"ride-sharing-notification/internal/pkg/preferences"
+	"ride-sharing-notification/internal/pkg/push"
+	"ride-sharing-notification/internal/pkg/ratelimit"
+	"ride-sharing-notification/internal/pkg/realtime"
+	"ride-sharing-notification/internal/pkg/receipt"
+	"ride-sharing-notification/internal/pkg/reload"
+	"ride-sharing-notification/internal/pkg/sms"
+	"ride-sharing-notification/internal/pkg/storage"
+	"ride-sharing-notification/internal/pkg/suppression"
+	"ride-sharing-notification/internal/pkg/throttle"
+	"ride-sharing-notification/internal/pkg/tlsconfig"
+	"ride-sharing-notification/internal/pkg/tracing"
+	"ride-sharing-notification/internal/pkg/webhook"
 	"syscall"
+	"time"
+
+	"go.uber.org/zap"
 )
 
 func main() {
@@ -21,25 +45,130 @@ func main() {
 	}
 
 	logging.InitLogger(logging.LogConfig{
-		Environment: cfg.Log.Environment,
-		Version:     cfg.Log.Version,
-		ServiceName: cfg.Log.ServiceName,
+		Environment:  cfg.Log.Environment,
+		Version:      cfg.Log.Version,
+		ServiceName:  cfg.Log.ServiceName,
+		MaskFields:   cfg.Log.MaskFields,
+		MaskHashSalt: cfg.Log.MaskHashSalt,
+		Level:        cfg.Log.Level,
+	})
+	logging.GetLogger().Info("configuration loaded", zap.String("config", cfg.Redacted()))
+	shutdownTracing, err := tracing.Init(context.Background(), cfg)
+	if err != nil {
+		log.Fatalf("failed to initialise tracing: %v", err)
+	}
+
+	throttler := throttle.NewFromConfig(cfg)
+	metrics.RegisterThrottler(throttler)
+	store, err := storage.NewFromConfig(cfg)
+	if err != nil {
+		log.Fatalf("failed to open storage: %v", err)
+	}
+	defer store.Close()
+	suppressions := suppression.NewList(store)
+	emailMessages := email.NewMessageLog(store, cfg.Bounce.MessageRetention)
+	emailSvc := email.NewService(cfg, throttler, emailMessages, suppressions)
+	smsSvc := sms.NewFromConfig(cfg, throttler, suppressions)
+	fcm, err := firebase.NewFromConfig(cfg)
+	if err != nil {
+		log.Fatalf("failed to configure FCM: %v", err)
+	}
+	bounces := bounce.NewFromConfig(emailMessages, suppressions, store, cfg)
+	preferencesSvc := preferences.NewService(store)
+	deviceRegistry := devices.NewRegistry(store, cfg.Push.DeviceTTL)
+	var pushProvider push.Provider
+	if fcm != nil {
+		pushProvider = fcm
+	}
+	pushSvc := push.NewService(pushProvider, throttler, deviceRegistry, suppressions)
+	pushSvc.ApplyConfig(cfg)
+	limiter, err := ratelimit.NewFromConfig(cfg)
+	if err != nil {
+		log.Fatalf("failed to create rate limiter: %v", err)
+	}
+	scheduler := notify.NewScheduler(store, cfg.Notify.ScheduleLease)
+	hub := realtime.NewHub(store, cfg.Realtime.ReplayLimit, cfg.Realtime.HeartbeatInterval)
+	inboxSvc := inbox.NewFromConfig(store, cfg)
+	webhooks := webhook.NewFromConfig(store, cfg)
+	dispatcher := notify.NewDispatcher(emailSvc, smsSvc, pushSvc, preferencesSvc, deviceRegistry, scheduler, hub, inboxSvc, limiter)
+	dispatcher.ApplyConfig(cfg)
+	authenticator, err := auth.NewFromConfig(cfg)
+	if err != nil {
+		log.Fatalf("failed to configure gRPC authentication: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	tlsConfig, err := tlsconfig.NewFromConfig(ctx, cfg)
+	if err != nil {
+		log.Fatalf("failed to load gRPC TLS configuration: %v", err)
+	}
+	// Apply reloadable settings on SIGHUP or when the config file changes
+	reloader := reload.NewManager(cfg, config.Load)
+	reloader.Register("logging", func(cfg *config.Config) {
+		if err := logging.SetLevel(cfg.Log.Level); err != nil {
+			logging.GetLogger().Warn("keeping current log level", zap.Error(err))
+		}
 	})
-	emailSvc := email.NewService(cfg)
+	reloader.Register("email", emailSvc.ApplyConfig)
+	reloader.Register("sms", smsSvc.ApplyConfig)
+	reloader.Register("push", pushSvc.ApplyConfig)
+	reloader.Register("notify", dispatcher.ApplyConfig)
+	reloader.Register("ratelimit", func(cfg *config.Config) {
+		limiter.SetRules(ratelimit.RulesFromConfig(cfg))
+	})
+	reloader.Register("throttle", func(cfg *config.Config) {
+		throttler.Replace(throttle.LimitsFromConfig(cfg))
+	})
+	configFile, _ := config.FilePath(cfg.Server.Environment)
+	go reloader.Watch(ctx, configFile, cfg.Server.ConfigPollInterval)
+
 	// Create gRPC server
-	grpcServer := rpc.NewGRPCServer(emailSvc)
+	grpcServer := rpc.NewGRPCServer(rpc.Services{
+		Email:         emailSvc,
+		EmailMessages: emailMessages,
+		SMS:           smsSvc,
+		Push:          pushSvc,
+		Dispatcher:    dispatcher,
+		Scheduler:     scheduler,
+		Preferences:   preferencesSvc,
+		Devices:       deviceRegistry,
+		Realtime:      hub,
+		Inbox:         inboxSvc,
+		Webhooks:      webhooks,
+		Suppressions:  suppressions,
+		Limiter:       limiter,
+	}, authenticator, tlsConfig)
+	grpcServer.SetTimeouts(cfg.GRPC.DefaultTimeout, cfg.GRPC.MethodTimeouts)
 
 	// Start server in a goroutine
 	go func() {
 		if err := grpcServer.Start(cfg.GRPC.Port); err != nil {
 		}
 	}()
+	// Start admin server exposing health and metrics
+	adminServer := admin.NewServer()
+	adminServer.HandleConfig(reloader, cfg.Server.AdminToken)
+	adminServer.HandleBounces(bounces, cfg.Bounce.WebhookToken)
+	go func() {
+		if err := adminServer.Start(cfg.Server.Port); err != nil {
+			logging.GetLogger().Error("admin server failed", zap.Error(err))
+		}
+	}()
 	// Start Kafka consumer
-	ctx, cancel := context.WithCancel(context.Background())
-	kafkaHandler := kafka.NewMessageHandler(emailSvc)
+	receipts := receipt.NewSender(emailSvc, preferencesSvc, store)
+	kafkaHandler := kafka.NewMessageHandler(dispatcher, receipts, webhooks)
 	consumer := kafka.NewConsumer(cfg.Kafka.Brokers, cfg.Kafka.Topic, cfg.Kafka.GroupId, kafkaHandler)
 
 	go consumer.Start(ctx)
+	if cfg.Kafka.RideTopic != "" {
+		rideConsumer := kafka.NewConsumer(cfg.Kafka.Brokers, cfg.Kafka.RideTopic, cfg.Kafka.RideGroupId, kafkaHandler)
+		go rideConsumer.Start(ctx)
+	}
+	// Release scheduled notifications and those held for quiet hours
+	go dispatcher.Run(ctx, cfg.Notify.SchedulePollInterval)
+	go inboxSvc.Run(ctx, cfg.Inbox.CleanupInterval)
+	go webhooks.Run(ctx, cfg.Webhook.PollInterval)
+	go bounces.Run(ctx, cfg.Bounce.PollInterval)
+	go receipts.Run(ctx, time.Hour)
 	// Wait for interrupt signal
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
@@ -51,6 +180,14 @@ func main() {
 	// ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	// Gracefully stop the server
+	// Gracefully stop the servers. Open notification streams are ended first
+	// so clients reconnect elsewhere instead of holding up the shutdown.
+	hub.Close()
 	grpcServer.Stop(ctx)
+	adminServer.Stop(ctx)
+
+	// Flush buffered spans
+	if err := shutdownTracing(ctx); err != nil {
+		logging.GetLogger().Warn("failed to flush traces", zap.Error(err))
+	}
 }
@@ -1,117 +1,483 @@
 package config
 
 import (
+	"fmt"
 	"log"
 	"os"
-	"strconv"
-	"strings"
+	"path/filepath"
 	"time"
 
 	"github.com/joho/godotenv"
 )
 
+// Environments with dedicated configuration files
+const (
+	EnvDev  = "dev"
+	EnvUAT  = "uat"
+	EnvProd = "prod"
+)
+
+// Config is layered at startup, each source overriding the previous one:
+//
+//  1. built-in defaults
+//  2. the per-environment YAML file (config/<env>.yaml or CONFIG_FILE)
+//  3. environment variables, including .env and .<env>.env files
+//  4. secret files for fields tagged secret (SECRETS_DIR, default /run/secrets)
+//
+// Fields map to YAML keys through the yaml tag and to environment variables
+// through the env tag. Fields tagged reload (or nested in a struct tagged
+// reload) are re-read on SIGHUP or when the YAML file changes; every other
+// change needs a restart.
 type Config struct {
 	Server struct {
-		Port        string
-		Environment string
-	}
+		Port        string `yaml:"port" env:"SERVER_PORT"`
+		Environment string `yaml:"environment" env:"ENVIRONMENT"`
+		// ConfigPollInterval is how often the YAML file is checked for
+		// changes; SIGHUP always triggers a reload
+		ConfigPollInterval time.Duration `yaml:"config_poll_interval" env:"CONFIG_POLL_INTERVAL"`
+		// AdminToken authenticates the configuration endpoints of the
+		// admin server; they are disabled without one
+		AdminToken string `yaml:"admin_token" env:"ADMIN_TOKEN" secret:"true"`
+	} `yaml:"server"`
 	JWT struct {
-		AccessSecret  string
-		RefreshSecret string
-	}
+		AccessSecret  string `yaml:"access_secret" env:"JWT_ACCESS_SECRET" secret:"true"`
+		RefreshSecret string `yaml:"refresh_secret" env:"JWT_REFRESH_SECRET" secret:"true"`
+	} `yaml:"jwt"`
 	Log struct {
-		Environment string
-		Version     string
-		ServiceName string
-	}
+		Environment string `yaml:"environment" env:"ENVIRONMENT"`
+		Version     string `yaml:"version" env:"VERSION"`
+		ServiceName string `yaml:"service_name" env:"SERVICE_NAME"`
+		// Level is the minimum level logged: debug, info, warn or error
+		Level string `yaml:"level" env:"LOG_LEVEL" reload:"true"`
+		// MaskFields maps log field names to a masking strategy
+		// (redact, partial, email, hash); nil keeps the built-in defaults
+		MaskFields   StringMap `yaml:"mask_fields" env:"LOG_MASK_FIELDS"`
+		MaskHashSalt string    `yaml:"mask_hash_salt" env:"LOG_MASK_HASH_SALT" secret:"true"`
+	} `yaml:"log"`
 	Email struct {
-		Enabled   bool
-		FromEmail string
-		Username  string
-		Password  string
-		SMTPHost  string
-		SMTPPort  string
-		Timeout   time.Duration
-	}
+		Enabled   bool          `yaml:"enabled" env:"EMAIL_ENABLED" reload:"true"`
+		FromEmail string        `yaml:"from_email" env:"EMAIL_FROM"`
+		Username  string        `yaml:"username" env:"EMAIL_USERNAME"`
+		Password  string        `yaml:"password" env:"EMAIL_PASSWORD" secret:"true"`
+		SMTPHost  string        `yaml:"smtp_host" env:"EMAIL_SMTP_HOST"`
+		SMTPPort  string        `yaml:"smtp_port" env:"EMAIL_SMTP_PORT"`
+		Timeout   time.Duration `yaml:"timeout" env:"EMAIL_TIMEOUT"`
+		Retry     RetryPolicy   `yaml:"retry" reload:"true"`
+	} `yaml:"email"`
+	SMS struct {
+		Enabled bool   `yaml:"enabled" env:"SMS_ENABLED" reload:"true"`
+		From    string `yaml:"from" env:"SMS_FROM"`
+		// MaxSegments rejects messages that would be split into more parts
+		MaxSegments int           `yaml:"max_segments" env:"SMS_MAX_SEGMENTS" reload:"true"`
+		Timeout     time.Duration `yaml:"timeout" env:"SMS_TIMEOUT"`
+		Retry       struct {
+			MaxAttempts int           `yaml:"max_attempts" env:"SMS_RETRY_MAX_ATTEMPTS"`
+			Delay       time.Duration `yaml:"delay" env:"SMS_RETRY_DELAY"`
+		} `yaml:"retry" reload:"true"`
+		// Weights splits traffic between configured providers by name.
+		// Providers with weight 0 only serve as fallback.
+		Weights IntMap `yaml:"weights" env:"SMS_PROVIDER_WEIGHTS" reload:"true"`
+		Twilio  struct {
+			AccountSID          string `yaml:"account_sid" env:"SMS_TWILIO_ACCOUNT_SID"`
+			AuthToken           string `yaml:"auth_token" env:"SMS_TWILIO_AUTH_TOKEN" secret:"true"`
+			MessagingServiceSID string `yaml:"messaging_service_sid" env:"SMS_TWILIO_MESSAGING_SERVICE_SID"`
+			// BaseURL points at Twilio or any API-compatible gateway
+			BaseURL string `yaml:"base_url" env:"SMS_TWILIO_BASE_URL"`
+		} `yaml:"twilio"`
+		HTTP struct {
+			URL        string `yaml:"url" env:"SMS_HTTP_URL"`
+			APIKey     string `yaml:"api_key" env:"SMS_HTTP_API_KEY" secret:"true"`
+			AuthHeader string `yaml:"auth_header" env:"SMS_HTTP_AUTH_HEADER"`
+		} `yaml:"http"`
+	} `yaml:"sms"`
+	Push struct {
+		Enabled bool          `yaml:"enabled" env:"PUSH_ENABLED" reload:"true"`
+		Timeout time.Duration `yaml:"timeout" env:"PUSH_TIMEOUT"`
+		// DeviceTTL is how long a registered device keeps receiving pushes
+		// without registering again; zero never expires devices
+		DeviceTTL time.Duration `yaml:"device_ttl" env:"PUSH_DEVICE_TTL"`
+		FCM       struct {
+			ProjectID string `yaml:"project_id" env:"PUSH_FCM_PROJECT_ID"`
+			// CredentialsFile is a Google service account key with the
+			// Firebase Cloud Messaging scope
+			CredentialsFile string `yaml:"credentials_file" env:"PUSH_FCM_CREDENTIALS_FILE"`
+			// Endpoint overrides the FCM API base URL, e.g. for an emulator
+			Endpoint string `yaml:"endpoint" env:"PUSH_FCM_ENDPOINT"`
+		} `yaml:"fcm"`
+	} `yaml:"push"`
+	// Realtime streams in-app notifications to connected apps
+	Realtime struct {
+		// HeartbeatInterval is how often open streams receive a heartbeat
+		HeartbeatInterval time.Duration `yaml:"heartbeat_interval" env:"REALTIME_HEARTBEAT_INTERVAL"`
+		// ReplayLimit is how many recent notifications are kept per user for
+		// apps resuming from a cursor
+		ReplayLimit int `yaml:"replay_limit" env:"REALTIME_REPLAY_LIMIT"`
+	} `yaml:"realtime"`
+	// Inbox keeps in-app notifications for users to read later
+	Inbox struct {
+		// TTL is how long items are kept; zero keeps them until MaxItems
+		// pushes them out
+		TTL time.Duration `yaml:"ttl" env:"INBOX_TTL"`
+		// ReadTTL is how long items are kept once read; zero uses TTL alone
+		ReadTTL time.Duration `yaml:"read_ttl" env:"INBOX_READ_TTL"`
+		// MaxItems per user; the oldest items are dropped beyond it
+		MaxItems int `yaml:"max_items" env:"INBOX_MAX_ITEMS"`
+		// CleanupInterval is how often expired items are deleted
+		CleanupInterval time.Duration `yaml:"cleanup_interval" env:"INBOX_CLEANUP_INTERVAL"`
+	} `yaml:"inbox"`
+	// Webhook delivers ride events to partner endpoints
+	Webhook struct {
+		// Timeout bounds each delivery attempt
+		Timeout time.Duration `yaml:"timeout" env:"WEBHOOK_TIMEOUT"`
+		// MaxAttempts per delivery before it is marked failed
+		MaxAttempts int `yaml:"max_attempts" env:"WEBHOOK_MAX_ATTEMPTS"`
+		// InitialBackoff doubles after every failed attempt up to MaxBackoff
+		InitialBackoff time.Duration `yaml:"initial_backoff" env:"WEBHOOK_INITIAL_BACKOFF"`
+		MaxBackoff     time.Duration `yaml:"max_backoff" env:"WEBHOOK_MAX_BACKOFF"`
+		// DisableAfter consecutive failed attempts disables a subscription;
+		// zero never disables
+		DisableAfter int `yaml:"disable_after" env:"WEBHOOK_DISABLE_AFTER"`
+		// PollInterval is how often queued deliveries are checked
+		PollInterval time.Duration `yaml:"poll_interval" env:"WEBHOOK_POLL_INTERVAL"`
+		// Lease hides a delivery being attempted from other instances
+		Lease time.Duration `yaml:"lease" env:"WEBHOOK_LEASE"`
+		// Concurrency is how many deliveries are attempted at once
+		Concurrency int `yaml:"concurrency" env:"WEBHOOK_CONCURRENCY"`
+		// LogLimit is how many deliveries are kept per subscription
+		LogLimit int `yaml:"log_limit" env:"WEBHOOK_LOG_LIMIT"`
+		// AllowHTTP accepts plain http endpoints; for local testing only
+		AllowHTTP bool `yaml:"allow_http" env:"WEBHOOK_ALLOW_HTTP"`
+		// AllowPrivateNetworks accepts endpoints on loopback and private
+		// addresses; for local testing only
+		AllowPrivateNetworks bool `yaml:"allow_private_networks" env:"WEBHOOK_ALLOW_PRIVATE_NETWORKS"`
+	} `yaml:"webhook"`
+	// Bounce processes bounce and complaint reports for sent emails
+	Bounce struct {
+		// Mbox is a mailbox file the bounce address delivers to; empty
+		// turns polling off
+		Mbox string `yaml:"mbox" env:"BOUNCE_MBOX"`
+		// PollInterval is how often the mailbox is read and old sent
+		// emails are forgotten
+		PollInterval time.Duration `yaml:"poll_interval" env:"BOUNCE_POLL_INTERVAL"`
+		// WebhookToken authenticates provider reports posted to the admin
+		// server; empty turns the endpoints off
+		WebhookToken string `yaml:"webhook_token" env:"BOUNCE_WEBHOOK_TOKEN" secret:"true"`
+		// MessageRetention is how long sent emails are kept to match
+		// reports against
+		MessageRetention time.Duration `yaml:"message_retention" env:"BOUNCE_MESSAGE_RETENTION"`
+	} `yaml:"bounce"`
+	// Notify configures SendNotification when the caller gives no channel
+	// preference
+	Notify struct {
+		DefaultChannels []string `yaml:"default_channels" env:"NOTIFY_DEFAULT_CHANNELS" reload:"true"`
+		// SchedulePollInterval is how often held notifications are checked
+		// for release
+		SchedulePollInterval time.Duration `yaml:"schedule_poll_interval" env:"NOTIFY_SCHEDULE_POLL_INTERVAL"`
+		// ScheduleLease is how long a notification being released stays
+		// claimed; if it has not been sent by then it is released again
+		ScheduleLease time.Duration `yaml:"schedule_lease" env:"NOTIFY_SCHEDULE_LEASE"`
+	} `yaml:"notify"`
+	// QuietHours holds non-urgent notifications while it is night for the
+	// recipient. Users and requests can override the window and time zone.
+	QuietHours struct {
+		Enabled bool `yaml:"enabled" env:"QUIET_HOURS_ENABLED"`
+		// Start and End are local "HH:MM" times; the window may span midnight
+		Start string `yaml:"start" env:"QUIET_HOURS_START"`
+		End   string `yaml:"end" env:"QUIET_HOURS_END"`
+		// TimeZone applies to users who have not set one
+		TimeZone string `yaml:"time_zone" env:"QUIET_HOURS_TIME_ZONE"`
+		// Categories are the preference categories that are held
+		Categories []string `yaml:"categories" env:"QUIET_HOURS_CATEGORIES"`
+		// UrgentTypes are always delivered immediately
+		UrgentTypes []string `yaml:"urgent_types" env:"QUIET_HOURS_URGENT_TYPES"`
+	} `yaml:"quiet_hours" reload:"true"`
+	// Digest batches notifications of the listed types per recipient and
+	// sends them as one message when the window closes. Requests marked
+	// urgent are always sent immediately.
+	Digest struct {
+		Enabled bool `yaml:"enabled" env:"DIGEST_ENABLED"`
+		// Types maps notification types to their window: hourly or daily
+		Types StringMap `yaml:"types" env:"DIGEST_TYPES"`
+		// DailyAt is the local "HH:MM" time daily digests go out, in the
+		// recipient's time zone or else quiet_hours.time_zone
+		DailyAt string `yaml:"daily_at" env:"DIGEST_DAILY_AT"`
+		// MaxItems caps the notifications listed in one digest; the rest
+		// are only counted
+		MaxItems int `yaml:"max_items" env:"DIGEST_MAX_ITEMS"`
+	} `yaml:"digest" reload:"true"`
+	// Storage holds user state such as preferences and device tokens
+	Storage struct {
+		// Driver is memory (lost on restart) or bolt (a local file)
+		Driver string `yaml:"driver" env:"STORAGE_DRIVER"`
+		Path   string `yaml:"path" env:"STORAGE_PATH"`
+	} `yaml:"storage"`
 	Kafka struct {
-		Brokers  []string
-		Topic    string
-		Balancer string
-		GroupId  string
-	}
+		Brokers []string `yaml:"brokers" env:"KAFKA_BROKERS"`
+		Topic   string   `yaml:"topic" env:"KAFKA_TOPIC"`
+		// RideTopic carries ride-service events; empty disables it
+		RideTopic string `yaml:"ride_topic" env:"KAFKA_RIDE_TOPIC"`
+		Balancer  string `yaml:"balancer" env:"KAFKA_BALANCER"`
+		GroupId   string `yaml:"group_id" env:"KAFKA_GROUP_ID"`
+		// RideGroupId is the consumer group of RideTopic, kept apart so a
+		// rebalance of one topic does not pause the other
+		RideGroupId string `yaml:"ride_group_id" env:"KAFKA_RIDE_GROUP_ID"`
+	} `yaml:"kafka"`
 	GRPC struct {
-		Port string
+		Port string `yaml:"port" env:"GRPC_PORT"`
+		// DefaultTimeout bounds requests that arrive without a deadline;
+		// MethodTimeouts overrides it per RPC name
+		DefaultTimeout time.Duration `yaml:"default_timeout" env:"GRPC_DEFAULT_TIMEOUT"`
+		MethodTimeouts DurationMap   `yaml:"method_timeouts" env:"GRPC_METHOD_TIMEOUTS"`
+		TLS            struct {
+			Enabled  bool   `yaml:"enabled" env:"GRPC_TLS_ENABLED"`
+			CertFile string `yaml:"cert_file" env:"GRPC_TLS_CERT_FILE"`
+			KeyFile  string `yaml:"key_file" env:"GRPC_TLS_KEY_FILE"`
+			// ClientCAFile enables mutual TLS: clients must present a
+			// certificate signed by this CA
+			ClientCAFile   string        `yaml:"client_ca_file" env:"GRPC_TLS_CLIENT_CA_FILE"`
+			ReloadInterval time.Duration `yaml:"reload_interval" env:"GRPC_TLS_RELOAD_INTERVAL"`
+		} `yaml:"tls"`
+	} `yaml:"grpc"`
+	Auth struct {
+		Enabled  bool   `yaml:"enabled" env:"GRPC_AUTH_ENABLED"`
+		JWKSFile string `yaml:"jwks_file" env:"GRPC_AUTH_JWKS_FILE"`
+		Issuer   string `yaml:"issuer" env:"GRPC_AUTH_ISSUER"`
+		Audience string `yaml:"audience" env:"GRPC_AUTH_AUDIENCE"`
+		// Allowlist maps an RPC name to the services allowed to call it
+		Allowlist Allowlist `yaml:"allowlist" env:"GRPC_AUTH_ALLOWLIST"`
+		// Delegates are the backend services that may act for any user by
+		// naming it in user_id; everyone else only acts for their subject
+		Delegates []string `yaml:"delegates" env:"GRPC_AUTH_DELEGATES"`
+	} `yaml:"auth"`
+	RateLimit struct {
+		Enabled bool           `yaml:"enabled" env:"RATE_LIMIT_ENABLED" reload:"true"`
+		Store   string         `yaml:"store" env:"RATE_LIMIT_STORE"`
+		Rules   RateLimitRules `yaml:"rules" env:"RATE_LIMIT_RULES" reload:"true"`
+	} `yaml:"rate_limit"`
+	Tracing struct {
+		Exporter    string  `yaml:"exporter" env:"TRACING_EXPORTER"`
+		Endpoint    string  `yaml:"endpoint" env:"OTEL_EXPORTER_OTLP_ENDPOINT"`
+		Insecure    bool    `yaml:"insecure" env:"TRACING_INSECURE"`
+		SampleRatio float64 `yaml:"sample_ratio" env:"TRACING_SAMPLE_RATIO"`
+	} `yaml:"tracing"`
+	// Throttle holds provider throughput limits keyed by channel or
+	// "channel:provider". Each key is overridable through
+	// THROTTLE_<KEY>_RATE and THROTTLE_<KEY>_BURST.
+	Throttle map[string]ThrottleLimit `yaml:"throttle" reload:"true"`
+}
+
+// RetryPolicy controls how often a failed send is attempted again
+type RetryPolicy struct {
+	MaxAttempts int           `yaml:"max_attempts" env:"EMAIL_RETRY_MAX_ATTEMPTS"`
+	Delay       time.Duration `yaml:"delay" env:"EMAIL_RETRY_DELAY"`
+}
+
+// ThrottleLimit is a token bucket refilled at Rate messages per second
+// holding at most Burst tokens
+type ThrottleLimit struct {
+	Rate  float64 `yaml:"rate"`
+	Burst int     `yaml:"burst"`
+}
+
+// RateLimitRule caps sends of the given notification types per window,
+// counted separately for each combination of the By dimensions
+// (recipient, type, caller)
+type RateLimitRule struct {
+	Name   string        `yaml:"name"`
+	By     []string      `yaml:"by"`
+	Types  []string      `yaml:"types"`
+	Limit  int64         `yaml:"limit"`
+	Window time.Duration `yaml:"window"`
+}
+
+// defaults returns the built-in configuration for environment
+func defaults(environment string) *Config {
+	cfg := &Config{}
+
+	cfg.Server.Port = "8080"
+	cfg.Server.Environment = environment
+	cfg.Server.ConfigPollInterval = 30 * time.Second
+
+	cfg.Log.Environment = environment
+	cfg.Log.Version = "1.0.0"
+	cfg.Log.ServiceName = "notification-service"
+	cfg.Log.Level = "info"
+	if environment == EnvDev {
+		cfg.Log.Level = "debug"
+	}
+
+	// Email configuration (Zoho Mail); credentials have no defaults
+	cfg.Email.Enabled = true
+	cfg.Email.SMTPHost = "smtp.zoho.com"
+	cfg.Email.SMTPPort = "587"
+	cfg.Email.Timeout = 10 * time.Second
+	cfg.Email.Retry = RetryPolicy{MaxAttempts: 3, Delay: time.Second}
+
+	// SMS is off until a provider is configured
+	cfg.SMS.Enabled = false
+	cfg.SMS.MaxSegments = 3
+	cfg.SMS.Timeout = 10 * time.Second
+	cfg.SMS.Retry.MaxAttempts = 2
+	cfg.SMS.Retry.Delay = 500 * time.Millisecond
+	cfg.SMS.Twilio.BaseURL = "https://api.twilio.com"
+	cfg.SMS.HTTP.AuthHeader = "Authorization"
+
+	// Push is off until FCM credentials are configured
+	cfg.Push.Enabled = false
+	cfg.Push.Timeout = 10 * time.Second
+	cfg.Push.DeviceTTL = 60 * 24 * time.Hour
+	cfg.Push.FCM.Endpoint = "https://fcm.googleapis.com"
+
+	cfg.Realtime.HeartbeatInterval = 30 * time.Second
+	cfg.Realtime.ReplayLimit = 100
+
+	cfg.Inbox.TTL = 30 * 24 * time.Hour
+	cfg.Inbox.ReadTTL = 7 * 24 * time.Hour
+	cfg.Inbox.MaxItems = 200
+	cfg.Inbox.CleanupInterval = time.Hour
+
+	cfg.Webhook.Timeout = 10 * time.Second
+	cfg.Webhook.MaxAttempts = 8
+	cfg.Webhook.InitialBackoff = 30 * time.Second
+	cfg.Webhook.MaxBackoff = time.Hour
+	cfg.Webhook.DisableAfter = 25
+	cfg.Webhook.PollInterval = 5 * time.Second
+	cfg.Webhook.Lease = time.Minute
+	cfg.Webhook.Concurrency = 4
+	cfg.Webhook.LogLimit = 100
+
+	cfg.Bounce.PollInterval = time.Minute
+	cfg.Bounce.MessageRetention = 30 * 24 * time.Hour
+
+	cfg.Notify.DefaultChannels = []string{"push", "sms", "email"}
+	cfg.Notify.SchedulePollInterval = 10 * time.Second
+	cfg.Notify.ScheduleLease = 2 * time.Minute
+
+	cfg.QuietHours.Enabled = true
+	cfg.QuietHours.Start = "22:00"
+	cfg.QuietHours.End = "07:00"
+	cfg.QuietHours.TimeZone = "UTC"
+	cfg.QuietHours.Categories = []string{"marketing"}
+	cfg.QuietHours.UrgentTypes = []string{"USER_REGISTER", "FORGET_PASSWORD", "RESET_PASSWORD", "DRIVER_ARRIVING"}
+
+	cfg.Digest.Enabled = true
+	cfg.Digest.Types = StringMap{
+		"DRIVER_PAYOUT":     "daily",
+		"DRIVER_RATING":     "daily",
+		"DOCUMENT_REMINDER": "daily",
 	}
+	cfg.Digest.DailyAt = "18:00"
+	cfg.Digest.MaxItems = 50
+
+	cfg.Storage.Driver = "memory"
+	cfg.Storage.Path = "data/notification.db"
+
+	cfg.Kafka.Brokers = []string{"localhost:9092"}
+	cfg.Kafka.Topic = "user-events"
+	cfg.Kafka.RideTopic = "ride-events"
+	cfg.Kafka.Balancer = "least-bytes"
+	cfg.Kafka.GroupId = "user-events-reader"
+	cfg.Kafka.RideGroupId = "ride-events-reader"
+
+	cfg.GRPC.Port = "50051"
+	cfg.GRPC.DefaultTimeout = 45 * time.Second
+	// Streams stay open until the client disconnects
+	cfg.GRPC.MethodTimeouts = DurationMap{"SubscribeNotifications": 0}
+	cfg.GRPC.TLS.ReloadInterval = 30 * time.Second
+
+	// gRPC authentication, on by default outside development
+	cfg.Auth.Enabled = environment != EnvDev
+	cfg.Auth.Audience = "notification-service"
+
+	// Default limits: at most 5 OTP emails per address per hour and a
+	// generous per-service ceiling to contain a misbehaving caller. Kafka
+	// events without a producer header have no caller and skip the latter.
+	cfg.RateLimit.Enabled = true
+	cfg.RateLimit.Store = "memory"
+	cfg.RateLimit.Rules = RateLimitRules{
+		{Name: "otp-per-recipient", By: []string{"recipient", "type"}, Types: []string{"USER_REGISTER", "FORGET_PASSWORD"}, Limit: 5, Window: time.Hour},
+		{Name: "per-caller", By: []string{"caller"}, Limit: 600, Window: time.Minute},
+	}
+
+	cfg.Tracing.Exporter = "none"
+	cfg.Tracing.Endpoint = "localhost:4317"
+	cfg.Tracing.Insecure = true
+	cfg.Tracing.SampleRatio = 1
+
+	cfg.Throttle = map[string]ThrottleLimit{
+		"email":      {Rate: 5, Burst: 5},
+		"sms:twilio": {Rate: 10, Burst: 10},
+		"sms:http":   {Rate: 10, Burst: 10},
+		"push:fcm":   {Rate: 100, Burst: 100},
+	}
+
+	return cfg
 }
 
 func Load() (*Config, error) {
-	// Load .env file
-	err := godotenv.Load()
-	if err != nil {
-		log.Println("Warning: No .env file found - using system environment variables")
+	environment := getEnv("ENVIRONMENT", EnvDev)
+
+	// Load .env files; variables already set in the process win
+	for _, file := range []string{"." + environment + ".env", ".env"} {
+		if err := godotenv.Load(file); err == nil {
+			log.Printf("loaded environment file %s", file)
+		}
 	}
+	environment = getEnv("ENVIRONMENT", environment)
 
-	cfg := &Config{}
+	cfg := defaults(environment)
+
+	configFile, explicit := FilePath(environment)
+	if err := loadYAML(cfg, configFile, explicit); err != nil {
+		return nil, err
+	}
 
-	// Server configuration
-	cfg.Server.Port = getEnv("SERVER_PORT", "8080")
-	cfg.Server.Environment = getEnv("ENVIRONMENT", "dev")
-
-	// Log configuration
-	cfg.Log.Environment = getEnv("ENVIRONMENT", "dev")
-	cfg.Log.Version = getEnv("VERSION", "1.0.0")
-	cfg.Log.ServiceName = getEnv("SERVICE_NAME", "notification-service")
-
-	// Email configuration (Zoho Mail)
-	cfg.Email.Enabled = getEnvAsBool("EMAIL_ENABLED", true)
-	cfg.Email.FromEmail = getEnv("EMAIL_FROM", "no-reply@yourdomain.com")
-	cfg.Email.Username = getEnv("EMAIL_USERNAME", "your-email@yourdomain.com")
-	cfg.Email.Password = getEnv("EMAIL_PASSWORD", "your-zoho-password")
-	cfg.Email.SMTPHost = getEnv("EMAIL_SMTP_HOST", "smtp.zoho.com")
-	cfg.Email.SMTPPort = getEnv("EMAIL_SMTP_PORT", "587")
-	cfg.Email.Timeout = getEnvAsDuration("EMAIL_TIMEOUT", 10*time.Second)
-
-	// Kafka configuration
-	cfg.Kafka.Brokers = []string{getEnv("KAFKA_BROKER", "localhost:9092")}
-	cfg.Kafka.Topic = getEnv("KAFKA_TOPIC", "user-events")
-	cfg.Kafka.Balancer = getEnv("KAFKA_BALANCER", "least-bytes")
-	cfg.Kafka.GroupId = getEnv("KAFKA_GROUP_ID", "user-events-reader")
-
-	// gRPC configuration
-	cfg.GRPC.Port = getEnv("GRPC_PORT", "50051")
+	if err := applyEnv(cfg); err != nil {
+		return nil, err
+	}
+	if err := applySecretFiles(cfg, getEnv("SECRETS_DIR", "/run/secrets")); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.Validate(); err != nil {
+		if environment != EnvDev {
+			return nil, err
+		}
+		log.Printf("Warning: %v", err)
+	}
 
 	return cfg, nil
 }
 
-// Helper functions
-func getEnv(key, defaultValue string) string {
-	if value, exists := os.LookupEnv(key); exists {
-		return value
+// FilePath returns the YAML file loaded for environment and whether it was
+// named explicitly through CONFIG_FILE
+func FilePath(environment string) (string, bool) {
+	if file, explicit := os.LookupEnv("CONFIG_FILE"); explicit {
+		return file, true
 	}
-	return defaultValue
+	return filepath.Join(getEnv("CONFIG_DIR", "config"), environment+".yaml"), false
 }
 
-func getEnvAsBool(key string, defaultValue bool) bool {
+// Helper functions
+func getEnv(key, defaultValue string) string {
 	if value, exists := os.LookupEnv(key); exists {
-		if boolValue, err := strconv.ParseBool(value); err == nil {
-			return boolValue
-		}
+		return value
 	}
 	return defaultValue
 }
 
-func getEnvAsDuration(key string, defaultValue time.Duration) time.Duration {
-	if value, exists := os.LookupEnv(key); exists {
-		if dur, err := time.ParseDuration(value); err == nil {
-			return dur
-		}
+// readSecretFile returns the trimmed contents of a mounted secret
+func readSecretFile(path string) (string, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("read secret file: %w", err)
 	}
-	return defaultValue
+	return trimTrailingNewline(string(raw)), nil
 }
 
-func getEnvAsSlice(key string, defaultValue []string, sep string) []string {
-	if value, exists := os.LookupEnv(key); exists {
-		return strings.Split(value, sep)
+func trimTrailingNewline(s string) string {
+	for len(s) > 0 && (s[len(s)-1] == '\n' || s[len(s)-1] == '\r') {
+		s = s[:len(s)-1]
 	}
-	return defaultValue
+	return s
 }
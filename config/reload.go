@@ -0,0 +1,63 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+)
+
+// Change is a field whose value differs between two configurations
+type Change struct {
+	// Field is the YAML path, e.g. "rate_limit.rules"
+	Field string
+	// Reloadable reports whether the change applies without a restart
+	Reloadable bool
+}
+
+// Diff lists the fields that differ between c and next
+func (c *Config) Diff(next *Config) []Change {
+	var changes []Change
+	walkPairs(reflect.ValueOf(c).Elem(), reflect.ValueOf(next).Elem(), "", false,
+		func(path string, current, updated reflect.Value, reloadable bool) {
+			if !reflect.DeepEqual(current.Interface(), updated.Interface()) {
+				changes = append(changes, Change{Field: path, Reloadable: reloadable})
+			}
+		})
+	return changes
+}
+
+// Reloaded returns a copy of c with the reloadable fields taken from next.
+// Fields that need a restart keep their current values.
+func (c *Config) Reloaded(next *Config) *Config {
+	merged := *c
+	walkPairs(reflect.ValueOf(&merged).Elem(), reflect.ValueOf(next).Elem(), "", false,
+		func(_ string, current, updated reflect.Value, reloadable bool) {
+			if reloadable {
+				current.Set(updated)
+			}
+		})
+	return &merged
+}
+
+// walkPairs visits the leaf fields of a and b side by side. A reload tag on
+// a struct field applies to everything nested inside it.
+func walkPairs(a, b reflect.Value, prefix string, reloadable bool, fn func(path string, a, b reflect.Value, reloadable bool)) {
+	t := a.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		name, _, _ := strings.Cut(sf.Tag.Get("yaml"), ",")
+		if name == "" {
+			name = strings.ToLower(sf.Name)
+		}
+		path := name
+		if prefix != "" {
+			path = prefix + "." + name
+		}
+		fieldReloadable := reloadable || sf.Tag.Get("reload") == "true"
+
+		if sf.Type.Kind() == reflect.Struct && sf.Tag.Get("env") == "" {
+			walkPairs(a.Field(i), b.Field(i), path, fieldReloadable, fn)
+			continue
+		}
+		fn(path, a.Field(i), b.Field(i), fieldReloadable)
+	}
+}
@@ -0,0 +1,186 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// envDecoder is implemented by config types parsed from a single env var
+type envDecoder interface {
+	DecodeEnv(value string) error
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// loadYAML overlays the YAML file onto cfg. A missing file is only an error
+// when it was requested explicitly.
+func loadYAML(cfg *Config, path string, required bool) error {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) && !required {
+			return nil
+		}
+		return fmt.Errorf("read config file: %w", err)
+	}
+
+	if err := yaml.Unmarshal(raw, cfg); err != nil {
+		return fmt.Errorf("parse config file %s: %w", path, err)
+	}
+	return nil
+}
+
+// applyEnv overrides every field tagged env whose variable is set
+func applyEnv(cfg *Config) error {
+	err := walkFields(reflect.ValueOf(cfg).Elem(), func(field reflect.Value, tag reflect.StructTag) error {
+		name := tag.Get("env")
+		if name == "" {
+			return nil
+		}
+		value, exists := os.LookupEnv(name)
+		if !exists {
+			return nil
+		}
+		if err := setField(field, value); err != nil {
+			return fmt.Errorf("invalid %s: %w", name, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// KAFKA_BROKER predates KAFKA_BROKERS and still names a single broker
+	if _, exists := os.LookupEnv("KAFKA_BROKERS"); !exists {
+		if broker, exists := os.LookupEnv("KAFKA_BROKER"); exists {
+			cfg.Kafka.Brokers = []string{broker}
+		}
+	}
+
+	return applyThrottleEnv(cfg)
+}
+
+// applyThrottleEnv reads THROTTLE_<KEY>_RATE and THROTTLE_<KEY>_BURST for
+// every configured throttle key, e.g. THROTTLE_SMS_TWILIO_RATE for "sms:twilio"
+func applyThrottleEnv(cfg *Config) error {
+	for key, limit := range cfg.Throttle {
+		prefix := "THROTTLE_" + strings.ToUpper(strings.NewReplacer(":", "_", "-", "_").Replace(key))
+
+		if value, exists := os.LookupEnv(prefix + "_RATE"); exists {
+			rate, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return fmt.Errorf("invalid %s_RATE: %w", prefix, err)
+			}
+			limit.Rate = rate
+		}
+		if value, exists := os.LookupEnv(prefix + "_BURST"); exists {
+			burst, err := strconv.Atoi(value)
+			if err != nil {
+				return fmt.Errorf("invalid %s_BURST: %w", prefix, err)
+			}
+			limit.Burst = burst
+		}
+		cfg.Throttle[key] = limit
+	}
+	return nil
+}
+
+// applySecretFiles fills secret fields from files. <ENV>_FILE names a file
+// explicitly; otherwise <dir>/<env in lower case> is used when it exists.
+func applySecretFiles(cfg *Config, dir string) error {
+	return walkFields(reflect.ValueOf(cfg).Elem(), func(field reflect.Value, tag reflect.StructTag) error {
+		name := tag.Get("env")
+		if tag.Get("secret") != "true" || name == "" {
+			return nil
+		}
+
+		path, explicit := os.LookupEnv(name + "_FILE")
+		if !explicit {
+			path = filepath.Join(dir, strings.ToLower(name))
+			if _, err := os.Stat(path); err != nil {
+				return nil
+			}
+		}
+
+		value, err := readSecretFile(path)
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		field.SetString(value)
+		return nil
+	})
+}
+
+// walkFields calls fn for every leaf field of the struct v, descending into
+// nested structs that carry no env tag of their own
+func walkFields(v reflect.Value, fn func(field reflect.Value, tag reflect.StructTag) error) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		field := v.Field(i)
+
+		if sf.Type.Kind() == reflect.Struct && sf.Tag.Get("env") == "" {
+			if err := walkFields(field, fn); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := fn(field, sf.Tag); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func setField(field reflect.Value, value string) error {
+	if decoder, ok := field.Addr().Interface().(envDecoder); ok {
+		return decoder.DecodeEnv(value)
+	}
+
+	if field.Type() == durationType {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		field.SetInt(int64(d))
+		return nil
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return err
+		}
+		field.SetInt(n)
+	case reflect.Float64:
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return err
+		}
+		field.SetFloat(f)
+	case reflect.Slice:
+		if field.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("unsupported slice type %s", field.Type())
+		}
+		field.Set(reflect.ValueOf(splitList(value)))
+	default:
+		return fmt.Errorf("unsupported field type %s", field.Type())
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// StringMap is configured in env vars as "key=value,other=value"
+type StringMap map[string]string
+
+func (m *StringMap) DecodeEnv(value string) error {
+	entries := make(StringMap)
+	for _, raw := range splitList(value) {
+		key, val, ok := strings.Cut(raw, "=")
+		if !ok {
+			return fmt.Errorf("invalid entry %q: expected key=value", raw)
+		}
+		entries[strings.TrimSpace(key)] = strings.TrimSpace(val)
+	}
+	*m = entries
+	return nil
+}
+
+// IntMap is configured in env vars as "key=1,other=2"
+type IntMap map[string]int
+
+func (m *IntMap) DecodeEnv(value string) error {
+	entries := make(IntMap)
+	for _, raw := range splitList(value) {
+		key, rawValue, ok := strings.Cut(raw, "=")
+		if !ok {
+			return fmt.Errorf("invalid entry %q: expected key=number", raw)
+		}
+		n, err := strconv.Atoi(strings.TrimSpace(rawValue))
+		if err != nil {
+			return fmt.Errorf("invalid entry %q: %w", raw, err)
+		}
+		entries[strings.TrimSpace(key)] = n
+	}
+	*m = entries
+	return nil
+}
+
+// DurationMap is configured in env vars as "Key=10s;Other=1m"
+type DurationMap map[string]time.Duration
+
+func (m *DurationMap) DecodeEnv(value string) error {
+	durations := make(DurationMap)
+	for _, raw := range strings.Split(value, ";") {
+		raw = strings.TrimSpace(raw)
+		if raw == "" {
+			continue
+		}
+
+		key, rawDuration, ok := strings.Cut(raw, "=")
+		if !ok {
+			return fmt.Errorf("invalid entry %q: expected key=duration", raw)
+		}
+		d, err := time.ParseDuration(strings.TrimSpace(rawDuration))
+		if err != nil {
+			return fmt.Errorf("invalid entry %q: %w", raw, err)
+		}
+		durations[strings.TrimSpace(key)] = d
+	}
+	*m = durations
+	return nil
+}
+
+// Allowlist maps an RPC name to service names. In env vars it is written as
+// "Method=svc1,svc2;OtherMethod=svc3".
+type Allowlist map[string][]string
+
+func (a *Allowlist) DecodeEnv(value string) error {
+	allowlist := make(Allowlist)
+	for _, raw := range strings.Split(value, ";") {
+		raw = strings.TrimSpace(raw)
+		if raw == "" {
+			continue
+		}
+
+		method, services, ok := strings.Cut(raw, "=")
+		method = strings.TrimSpace(method)
+		if !ok || method == "" {
+			return fmt.Errorf("invalid allowlist entry %q: expected Method=service,...", raw)
+		}
+		allowlist[method] = splitList(services)
+	}
+	*a = allowlist
+	return nil
+}
+
+// RateLimitRules are written in env vars as
+// "name:by1,by2:TYPE1,TYPE2:limit:window" separated by semicolons. An empty
+// types field applies the rule to every notification type.
+type RateLimitRules []RateLimitRule
+
+func (r *RateLimitRules) DecodeEnv(value string) error {
+	var rules RateLimitRules
+	for _, raw := range strings.Split(value, ";") {
+		raw = strings.TrimSpace(raw)
+		if raw == "" {
+			continue
+		}
+
+		fields := strings.Split(raw, ":")
+		if len(fields) != 5 {
+			return fmt.Errorf("invalid rate limit rule %q: expected name:by:types:limit:window", raw)
+		}
+
+		limit, err := strconv.ParseInt(fields[3], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid rate limit rule %q: %w", raw, err)
+		}
+		window, err := time.ParseDuration(fields[4])
+		if err != nil {
+			return fmt.Errorf("invalid rate limit rule %q: %w", raw, err)
+		}
+
+		rules = append(rules, RateLimitRule{
+			Name:   fields[0],
+			By:     splitList(fields[1]),
+			Types:  splitList(fields[2]),
+			Limit:  limit,
+			Window: window,
+		})
+	}
+	*r = rules
+	return nil
+}
+
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
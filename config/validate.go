@@ -0,0 +1,201 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const redactedValue = "****"
+
+// Validate checks required fields and value ranges, reporting every problem
+// at once
+func (c *Config) Validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...any) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+
+	switch c.Server.Environment {
+	case EnvDev, EnvUAT, EnvProd:
+	default:
+		errs = append(errs, fmt.Errorf("server.environment must be one of dev, uat, prod, got %q", c.Server.Environment))
+	}
+	check(validPort(c.Server.Port), "server.port must be a port number, got %q", c.Server.Port)
+	check(validPort(c.GRPC.Port), "grpc.port must be a port number, got %q", c.GRPC.Port)
+	check(c.GRPC.DefaultTimeout >= 0, "grpc.default_timeout must not be negative")
+	check(c.Log.ServiceName != "", "log.service_name is required")
+	switch c.Log.Level {
+	case "debug", "info", "warn", "error":
+	default:
+		errs = append(errs, fmt.Errorf("log.level must be one of debug, info, warn, error, got %q", c.Log.Level))
+	}
+
+	if c.Email.Enabled {
+		check(c.Email.SMTPHost != "", "email.smtp_host is required when email is enabled")
+		check(validPort(c.Email.SMTPPort), "email.smtp_port must be a port number, got %q", c.Email.SMTPPort)
+		check(c.Email.Username != "", "email.username is required when email is enabled")
+		check(c.Email.Password != "", "email.password is required when email is enabled")
+		check(c.Email.FromEmail != "" || c.Email.Username != "", "email.from_email is required when email is enabled")
+		check(c.Email.Timeout > 0, "email.timeout must be positive")
+		check(c.Email.Retry.MaxAttempts > 0, "email.retry.max_attempts must be at least 1")
+		check(c.Email.Retry.Delay >= 0, "email.retry.delay must not be negative")
+	}
+
+	if c.SMS.Enabled {
+		twilio := c.SMS.Twilio.AccountSID != ""
+		gateway := c.SMS.HTTP.URL != ""
+		check(twilio || gateway, "sms requires sms.twilio.account_sid or sms.http.url when enabled")
+		if twilio {
+			check(c.SMS.Twilio.AuthToken != "", "sms.twilio.auth_token is required with sms.twilio.account_sid")
+			check(c.SMS.From != "" || c.SMS.Twilio.MessagingServiceSID != "", "sms.from or sms.twilio.messaging_service_sid is required for twilio")
+		}
+		check(c.SMS.MaxSegments > 0, "sms.max_segments must be positive")
+		check(c.SMS.Timeout > 0, "sms.timeout must be positive")
+		check(c.SMS.Retry.MaxAttempts > 0, "sms.retry.max_attempts must be at least 1")
+	}
+	for name, weight := range c.SMS.Weights {
+		check(name == "twilio" || name == "http", "sms.weights: unknown provider %q", name)
+		check(weight >= 0, "sms.weights[%s]: weight must not be negative", name)
+	}
+
+	check(c.Push.DeviceTTL >= 0, "push.device_ttl must not be negative")
+	if c.Push.Enabled {
+		check(c.Push.FCM.ProjectID != "", "push.fcm.project_id is required when push is enabled")
+		check(c.Push.FCM.CredentialsFile != "", "push.fcm.credentials_file is required when push is enabled")
+		check(c.Push.Timeout > 0, "push.timeout must be positive")
+	}
+	for _, channel := range c.Notify.DefaultChannels {
+		check(channel == "email" || channel == "sms" || channel == "push" || channel == "in_app",
+			"notify.default_channels: unknown channel %q", channel)
+	}
+
+	check(c.Realtime.HeartbeatInterval > 0, "realtime.heartbeat_interval must be positive")
+	check(c.Realtime.ReplayLimit > 0, "realtime.replay_limit must be positive")
+
+	check(c.Inbox.TTL >= 0, "inbox.ttl must not be negative")
+	check(c.Inbox.ReadTTL >= 0, "inbox.read_ttl must not be negative")
+	check(c.Inbox.MaxItems > 0, "inbox.max_items must be positive")
+	check(c.Inbox.CleanupInterval > 0, "inbox.cleanup_interval must be positive")
+
+	check(c.Webhook.Timeout > 0, "webhook.timeout must be positive")
+	check(c.Webhook.MaxAttempts > 0, "webhook.max_attempts must be positive")
+	check(c.Webhook.InitialBackoff > 0, "webhook.initial_backoff must be positive")
+	check(c.Webhook.MaxBackoff >= c.Webhook.InitialBackoff, "webhook.max_backoff must not be less than webhook.initial_backoff")
+	check(c.Webhook.DisableAfter >= 0, "webhook.disable_after must not be negative")
+	check(c.Webhook.PollInterval > 0, "webhook.poll_interval must be positive")
+	check(c.Webhook.Lease > c.Webhook.Timeout, "webhook.lease must be longer than webhook.timeout")
+	check(c.Webhook.Concurrency > 0, "webhook.concurrency must be positive")
+	check(c.Webhook.LogLimit > 0, "webhook.log_limit must be positive")
+
+	check(c.Bounce.PollInterval > 0, "bounce.poll_interval must be positive")
+	check(c.Bounce.MessageRetention >= 0, "bounce.message_retention must not be negative")
+
+	check(c.Notify.SchedulePollInterval > 0, "notify.schedule_poll_interval must be positive")
+	check(c.Notify.ScheduleLease > 0, "notify.schedule_lease must be positive")
+
+	if c.QuietHours.Enabled {
+		_, startErr := time.Parse("15:04", c.QuietHours.Start)
+		_, endErr := time.Parse("15:04", c.QuietHours.End)
+		check(startErr == nil && endErr == nil, "quiet_hours.start and quiet_hours.end must be HH:MM")
+		_, tzErr := time.LoadLocation(c.QuietHours.TimeZone)
+		check(tzErr == nil, "quiet_hours.time_zone: unknown time zone %q", c.QuietHours.TimeZone)
+		for _, category := range c.QuietHours.Categories {
+			check(category == "transactional" || category == "ride_updates" || category == "marketing" || category == "driver_updates",
+				"quiet_hours.categories: unknown category %q", category)
+		}
+	}
+
+	if c.Digest.Enabled {
+		_, dailyErr := time.Parse("15:04", c.Digest.DailyAt)
+		check(dailyErr == nil, "digest.daily_at must be HH:MM")
+		for notificationType, window := range c.Digest.Types {
+			check(window == "hourly" || window == "daily",
+				"digest.types[%s]: window must be hourly or daily, got %q", notificationType, window)
+		}
+		check(c.Digest.MaxItems > 0, "digest.max_items must be positive")
+	}
+
+	switch c.Storage.Driver {
+	case "memory":
+	case "bolt":
+		check(c.Storage.Path != "", "storage.path is required for the bolt driver")
+	default:
+		errs = append(errs, fmt.Errorf("storage.driver must be memory or bolt, got %q", c.Storage.Driver))
+	}
+
+	check(len(c.Kafka.Brokers) > 0, "kafka.brokers must list at least one broker")
+	check(c.Kafka.Topic != "", "kafka.topic is required")
+	check(c.Kafka.GroupId != "", "kafka.group_id is required")
+	check(c.Kafka.RideTopic == "" || c.Kafka.RideGroupId != "", "kafka.ride_group_id is required with kafka.ride_topic")
+	check(c.Kafka.RideTopic == "" || c.Kafka.RideGroupId != c.Kafka.GroupId, "kafka.ride_group_id must differ from kafka.group_id")
+
+	if c.GRPC.TLS.Enabled {
+		check(c.GRPC.TLS.CertFile != "" && c.GRPC.TLS.KeyFile != "", "grpc.tls.cert_file and grpc.tls.key_file are required when TLS is enabled")
+	}
+	check(c.GRPC.TLS.ClientCAFile == "" || c.GRPC.TLS.Enabled, "grpc.tls.client_ca_file requires grpc.tls.enabled")
+
+	if c.Auth.Enabled {
+		hasKeys := c.JWT.AccessSecret != "" || c.Auth.JWKSFile != ""
+		check(hasKeys || c.GRPC.TLS.ClientCAFile != "", "auth requires a JWT access secret, a JWKS file or a mutual TLS client CA")
+	}
+
+	if c.RateLimit.Enabled {
+		for _, rule := range c.RateLimit.Rules {
+			check(rule.Name != "", "rate_limit.rules: every rule needs a name")
+			check(rule.Limit > 0, "rate_limit.rules[%s]: limit must be positive", rule.Name)
+			check(rule.Window > 0, "rate_limit.rules[%s]: window must be positive", rule.Name)
+			for _, dim := range rule.By {
+				check(dim == "recipient" || dim == "type" || dim == "caller",
+					"rate_limit.rules[%s]: unknown dimension %q", rule.Name, dim)
+			}
+		}
+	}
+
+	switch c.Tracing.Exporter {
+	case "none", "stdout":
+	case "otlp":
+		check(c.Tracing.Endpoint != "", "tracing.endpoint is required for the otlp exporter")
+	default:
+		errs = append(errs, fmt.Errorf("tracing.exporter must be one of none, stdout, otlp, got %q", c.Tracing.Exporter))
+	}
+	check(c.Tracing.SampleRatio >= 0 && c.Tracing.SampleRatio <= 1, "tracing.sample_ratio must be between 0 and 1")
+
+	for key, limit := range c.Throttle {
+		check(limit.Rate >= 0 && limit.Burst >= 0, "throttle[%s]: rate and burst must not be negative", key)
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid configuration: %w", errors.Join(errs...))
+	}
+	return nil
+}
+
+// Redacted renders the configuration as YAML with secret values masked,
+// suitable for logging at startup
+func (c *Config) Redacted() string {
+	clone := *c
+	_ = walkFields(reflect.ValueOf(&clone).Elem(), func(field reflect.Value, tag reflect.StructTag) error {
+		if tag.Get("secret") == "true" && field.String() != "" {
+			field.SetString(redactedValue)
+		}
+		return nil
+	})
+
+	out, err := yaml.Marshal(&clone)
+	if err != nil {
+		return fmt.Sprintf("<unprintable config: %v>", err)
+	}
+	return string(out)
+}
+
+func validPort(port string) bool {
+	n, err := strconv.Atoi(port)
+	return err == nil && n > 0 && n <= 65535
+}
@@ -0,0 +1,84 @@
+package admin
+
+import (
+	"io"
+	"net/http"
+
+	"ride-sharing-notification/internal/pkg/bounce"
+	"ride-sharing-notification/internal/pkg/logging"
+
+	"go.uber.org/zap"
+)
+
+// maxReportSize bounds the body of a posted bounce report
+const maxReportSize = 1 << 20
+
+type bouncesResponse struct {
+	Processed int `json:"processed"`
+}
+
+// HandleBounces accepts bounce and complaint reports from email providers:
+// Amazon SES notifications at POST /bounces/ses and reports in our own
+// format at POST /bounces. Requests carry token as a bearer token; SNS
+// cannot set headers, so the SES endpoint also takes it in the token query
+// parameter. Nothing is registered without a token.
+func (s *Server) HandleBounces(p *bounce.Processor, token string) {
+	if token == "" {
+		return
+	}
+
+	s.mux.HandleFunc("POST /bounces/ses", requireQueryToken(token, func(w http.ResponseWriter, r *http.Request) {
+		body, ok := readReport(w, r)
+		if !ok {
+			return
+		}
+		reports, subscribeURL, err := bounce.ParseSES(body)
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, errorResponse{Error: err.Error()})
+			return
+		}
+		if subscribeURL != "" {
+			if err := bounce.ConfirmSubscription(r.Context(), subscribeURL); err != nil {
+				logging.GetLogger().Warn("failed to confirm SNS subscription", zap.Error(err))
+				writeJSON(w, http.StatusBadRequest, errorResponse{Error: err.Error()})
+				return
+			}
+			logging.GetLogger().Info("confirmed SNS subscription for bounce reports")
+		}
+		processReports(w, r, p, reports)
+	}))
+
+	s.mux.HandleFunc("POST /bounces", requireToken(token, func(w http.ResponseWriter, r *http.Request) {
+		body, ok := readReport(w, r)
+		if !ok {
+			return
+		}
+		reports, err := bounce.ParseJSON(body)
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, errorResponse{Error: err.Error()})
+			return
+		}
+		processReports(w, r, p, reports)
+	}))
+}
+
+// readReport returns the body of a posted report
+func readReport(w http.ResponseWriter, r *http.Request) ([]byte, bool) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxReportSize))
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "failed to read body"})
+		return nil, false
+	}
+	return body, true
+}
+
+// processReports answers 500 when a report could not be stored, so the
+// provider retries it
+func processReports(w http.ResponseWriter, r *http.Request, p *bounce.Processor, reports []bounce.Report) {
+	if err := p.ProcessAll(r.Context(), reports); err != nil {
+		logging.GetLogger().WithContext(r.Context()).Error("failed to process bounce reports", zap.Error(err))
+		writeJSON(w, http.StatusInternalServerError, errorResponse{Error: "failed to process reports"})
+		return
+	}
+	writeJSON(w, http.StatusOK, bouncesResponse{Processed: len(reports)})
+}
@@ -0,0 +1,55 @@
+package admin
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"ride-sharing-notification/internal/pkg/reload"
+)
+
+// configResponse is returned by the configuration endpoints
+type configResponse struct {
+	Status  reload.Status `json:"status"`
+	Changed []string      `json:"changed,omitempty"`
+	Config  string        `json:"config"`
+}
+
+// HandleConfig exposes the running configuration with secrets redacted at
+// GET /config and triggers a reload at POST /config/reload. Requests carry
+// token as a bearer token; nothing is registered without one.
+func (s *Server) HandleConfig(manager *reload.Manager, token string) {
+	if token == "" {
+		return
+	}
+
+	s.mux.HandleFunc("GET /config", requireToken(token, func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, http.StatusOK, configResponse{
+			Status: manager.Status(),
+			Config: manager.Current().Redacted(),
+		})
+	}))
+
+	s.mux.HandleFunc("POST /config/reload", requireToken(token, func(w http.ResponseWriter, _ *http.Request) {
+		changes, err := manager.Reload()
+		code := http.StatusOK
+		if err != nil {
+			code = http.StatusUnprocessableEntity
+		}
+
+		changed := make([]string, 0, len(changes))
+		for _, change := range changes {
+			changed = append(changed, change.Field)
+		}
+		writeJSON(w, code, configResponse{
+			Status:  manager.Status(),
+			Changed: changed,
+			Config:  manager.Current().Redacted(),
+		})
+	}))
+}
+
+func writeJSON(w http.ResponseWriter, code int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -2,9 +2,28 @@ package kafka
 
 import (
 	"context"
+	"strconv"
+	"sync"
 	"time"
 
+	"ride-sharing-notification/internal/pkg/logging"
+	"ride-sharing-notification/internal/pkg/metrics"
+	"ride-sharing-notification/internal/pkg/tracing"
+
 	"github.com/segmentio/kafka-go"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+const (
+	// partitionQueueSize bounds the fetched messages waiting per partition
+	partitionQueueSize = 64
+	// retryDelay doubles after every failed attempt at a message up to
+	// maxRetryDelay
+	retryDelay    = time.Second
+	maxRetryDelay = 30 * time.Second
 )
 
 type Consumer struct {
@@ -33,37 +52,116 @@ func NewConsumer(broker []string, topic string, groupID string, handler Handler)
 	}
 }
 
+// Start consumes messages until ctx is done and then closes the reader.
+// Messages of a partition are handled in order by one worker and committed
+// only once handled, so a failed message is retried instead of lost.
 func (c *Consumer) Start(ctx context.Context) {
+	var wg sync.WaitGroup
+	workers := make(map[int]chan kafka.Message)
+	defer func() {
+		for _, queue := range workers {
+			close(queue)
+		}
+		wg.Wait()
+		if err := c.reader.Close(); err != nil {
+			logging.GetLogger().Warn("failed to close kafka reader", zap.Error(err))
+		}
+	}()
+
 	for {
-		select {
-		case <-ctx.Done():
-			return
-		default:
-			m, err := c.reader.ReadMessage(ctx)
-			if err != nil {
-				if ctx.Err() != nil {
-					return
-				}
-				time.Sleep(1 * time.Second)
-				continue
+		m, err := c.reader.FetchMessage(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
 			}
+			logging.GetLogger().Warn("failed to read kafka message", zap.Error(err))
+			time.Sleep(1 * time.Second)
+			continue
+		}
 
-			// Process message in a goroutine
-			go func(msg kafka.Message) {
-				// Process the message
-				if err := c.handler.Handle(msg); err != nil {
-					return
-				}
+		// Lag is how far this partition's newest message is ahead of us
+		metrics.KafkaConsumerLag.
+			WithLabelValues(m.Topic, strconv.Itoa(m.Partition)).
+			Set(float64(m.HighWaterMark - m.Offset - 1))
 
-				// If processing succeeds, commit the offset
-				if err := c.reader.CommitMessages(ctx, msg); err != nil {
-					return
+		queue, ok := workers[m.Partition]
+		if !ok {
+			queue = make(chan kafka.Message, partitionQueueSize)
+			workers[m.Partition] = queue
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for msg := range queue {
+					c.process(ctx, msg)
 				}
-			}(m)
+			}()
+		}
+		// A full queue holds back fetching until the partition catches up
+		select {
+		case queue <- m:
+		case <-ctx.Done():
+			return
 		}
 	}
 }
 
+// process handles msg until it succeeds and then commits its offset. A
+// failing message is retried with backoff and holds back the rest of its
+// partition, as committing a later offset would skip it. Handlers drop
+// messages that can never succeed by returning nil.
+func (c *Consumer) process(ctx context.Context, msg kafka.Message) {
+	delay := retryDelay
+	for {
+		err := c.handle(ctx, msg)
+		if err == nil {
+			break
+		}
+		if ctx.Err() != nil {
+			// Redelivered after the restart since it is not committed
+			return
+		}
+		messageLogger(ctx, msg).Warn("retrying kafka message", zap.Duration("delay", delay), zap.Error(err))
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+		delay = min(delay*2, maxRetryDelay)
+	}
+
+	// The message was handled, so its commit must not be dropped on shutdown
+	if err := c.reader.CommitMessages(context.WithoutCancel(ctx), msg); err != nil {
+		messageLogger(ctx, msg).Error("failed to commit offset", zap.Error(err))
+	}
+}
+
+// handle runs the handler for one attempt at msg
+func (c *Consumer) handle(ctx context.Context, msg kafka.Message) error {
+	// Continue the producer's trace and correlation IDs from the message headers
+	msgCtx, span := tracing.Start(
+		tracing.Extract(messageContext(ctx, msg), headerCarrier{headers: &msg.Headers}),
+		"kafka.process "+msg.Topic,
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			semconv.MessagingSystemKafka,
+			semconv.MessagingDestinationName(msg.Topic),
+			semconv.MessagingKafkaMessageOffset(int(msg.Offset)),
+			attribute.Int("messaging.kafka.partition", msg.Partition),
+		),
+	)
+	defer span.End()
+
+	start := time.Now()
+	err := c.handler.Handle(msgCtx, msg)
+	status := "ok"
+	if err != nil {
+		status = "error"
+		tracing.RecordError(span, err)
+	}
+	metrics.KafkaProcessingDuration.WithLabelValues(msg.Topic, status).Observe(time.Since(start).Seconds())
+	return err
+}
+
 func (c *Consumer) Close() error {
 	return c.reader.Close()
 }
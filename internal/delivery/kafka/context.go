@@ -0,0 +1,46 @@
+package kafka
+
+import (
+	"context"
+
+	"ride-sharing-notification/internal/pkg/logging"
+
+	"github.com/segmentio/kafka-go"
+	"go.uber.org/zap"
+)
+
+// messageContext carries the request and correlation IDs from the message
+// headers into ctx, generating fresh IDs when the producer did not set them,
+// so logs on the Kafka path correlate the same way as gRPC requests. The
+// caller is only set when the producer names itself, so per-caller rate
+// limits count producers separately rather than a whole topic as one.
+func messageContext(ctx context.Context, msg kafka.Message) context.Context {
+	headers := headerCarrier{headers: &msg.Headers}
+
+	requestID := headers.Get(logging.RequestIDKey)
+	if requestID == "" {
+		requestID = logging.GenerateID()
+	}
+
+	correlationID := headers.Get(logging.CorrelationID)
+	if correlationID == "" {
+		correlationID = logging.GenerateID()
+	}
+
+	ctx = context.WithValue(ctx, logging.RequestIDKey, requestID)
+	ctx = context.WithValue(ctx, logging.CorrelationID, correlationID)
+	if caller := headers.Get(logging.CallerService); caller != "" {
+		ctx = context.WithValue(ctx, logging.CallerService, caller)
+	}
+	return ctx
+}
+
+// messageLogger returns the context logger annotated with the message position
+func messageLogger(ctx context.Context, msg kafka.Message) *logging.Logger {
+	return logging.GetLogger().WithContext(ctx).With(
+		zap.String("topic", msg.Topic),
+		zap.Int("partition", msg.Partition),
+		zap.Int64("offset", msg.Offset),
+		zap.ByteString("key", msg.Key),
+	)
+}
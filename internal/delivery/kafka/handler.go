@@ -1,43 +1,206 @@
 package kafka
 
 import (
+	"context"
 	"encoding/json"
-	"log"
-	"ride-sharing-notification/internal/pkg/email"
+	"fmt"
+	"ride-sharing-notification/internal/pkg/logging"
+	"ride-sharing-notification/internal/pkg/notify"
+	"ride-sharing-notification/internal/pkg/preferences"
+	"ride-sharing-notification/internal/pkg/receipt"
+	"ride-sharing-notification/internal/pkg/webhook"
+	"time"
 
 	"github.com/segmentio/kafka-go"
+	"go.uber.org/zap"
 )
 
 type Handler interface {
-	Handle(msg kafka.Message) error
+	Handle(ctx context.Context, msg kafka.Message) error
 }
 
+// Channels a message can be routed to through its "channel" field
+const (
+	channelEmail = "email"
+	channelSMS   = "sms"
+)
+
 type MessageHandler struct {
-	emailSvc *email.Service
+	dispatcher *notify.Dispatcher
+	receipts   *receipt.Sender
+	webhooks   *webhook.Service
 }
 
-func NewMessageHandler(emailSvc *email.Service) *MessageHandler {
+func NewMessageHandler(dispatcher *notify.Dispatcher, receipts *receipt.Sender, webhooks *webhook.Service) *MessageHandler {
 	return &MessageHandler{
-		emailSvc: emailSvc,
+		dispatcher: dispatcher,
+		receipts:   receipts,
+		webhooks:   webhooks,
 	}
 }
 
-func (h *MessageHandler) Handle(msg kafka.Message) error {
+// Handle routes a notification event to its channel. Events without a
+// "channel" field are emails. Notifications go through the dispatcher, so
+// opt-outs of the user in "user_id", rate limits, suppressions, digests and
+// quiet hours apply; "urgent": true skips digests and quiet hours. Events
+// with a future "send_at" (RFC 3339 or Unix seconds) are stored and sent
+// through the same channel at that time. Ride-service events, identified
+// by an "event" field, go to the rider or driver and to the account's
+// webhooks.
+func (h *MessageHandler) Handle(ctx context.Context, msg kafka.Message) error {
+	logger := messageLogger(ctx, msg)
+
 	var payload map[string]interface{}
 
+	// Malformed events can never succeed, so they are dropped rather than
+	// retried
 	if err := json.Unmarshal(msg.Value, &payload); err != nil {
+		logger.Error("dropping message that failed to decode", zap.Error(err))
+		return nil
+	}
+
+	if event, ok := payload["event"].(string); ok {
+		return h.handleRideEvent(ctx, logger, msg, event, payload)
+	}
+
+	to, _ := payload["to"].(string)
+	notificationType, _ := payload["type"].(string)
+	channel, _ := payload["channel"].(string)
+	if channel == "" {
+		channel = channelEmail
+	}
+	if to == "" || notificationType == "" {
+		logger.Error("dropping message missing required fields \"to\" and \"type\"")
+		return nil
+	}
+
+	logger = logger.With(
+		zap.String("channel", channel),
+		zap.String("type", notificationType),
+		zap.String("to", logging.Mask("to", to)),
+	)
+
+	userID, _ := payload["user_id"].(string)
+	urgent, _ := payload["urgent"].(bool)
+	req := &notify.Request{
+		ID:        eventID(msg, payload),
+		Recipient: notify.Recipient{UserID: userID},
+		Type:      notificationType,
+		Data:      payload,
+		Channels:  []string{channel},
+		Urgent:    urgent,
+	}
+	switch channel {
+	case channelEmail:
+		req.Recipient.Email = to
+	case channelSMS:
+		req.Recipient.Phone = to
+	default:
+		// Unknown channels can never succeed, so the event is dropped
+		logger.Error("dropping message for unknown channel")
+		return nil
+	}
+
+	if raw, ok := payload["send_at"]; ok {
+		sendAt, err := parseSendAt(raw)
+		if err != nil {
+			logger.Error("dropping message with invalid send_at", zap.Error(err))
+			return nil
+		}
+		if sendAt.After(time.Now()) {
+			return h.schedule(ctx, logger, req, sendAt)
+		}
+	}
+	return h.send(ctx, logger, req)
+}
+
+// send dispatches req. Temporary failures are left uncommitted so the event
+// is redelivered, rate-limited notifications are scheduled for when the
+// limit resets, and notifications that can never be delivered are dropped.
+func (h *MessageHandler) send(ctx context.Context, logger *logging.Logger, req *notify.Request) error {
+	logger.Info("processing notification event")
+	outcome, err := h.dispatcher.Send(ctx, req)
+	if err != nil {
+		logger.Error("failed to send notification", zap.Error(err))
 		return err
 	}
 
-	log.Printf("Sending OTP to: %s (otp: %s)", payload["to"], payload["otp"])
+	switch {
+	case outcome.OptedOut:
+		logger.Info("user opted out, skipping message",
+			zap.String("category", string(preferences.CategoryOf(req.Type))),
+		)
+	case outcome.Held():
+		logger.Info("notification held",
+			zap.String("reason", outcome.HeldReason),
+			zap.Time("held_until", outcome.HeldUntil),
+		)
+	case rateLimited(outcome) != nil:
+		return h.retryRateLimited(ctx, logger, req, rateLimited(outcome))
+	case !outcome.Delivered():
+		attempt := outcome.Attempts[len(outcome.Attempts)-1]
+		logger.Warn("dropping undeliverable notification",
+			zap.String("status", attempt.Status),
+			zap.String("reason", attempt.Reason),
+		)
+	default:
+		logger.Info("notification sent", zap.String("message_id", outcome.MessageID))
+	}
+	return nil
+}
+
+// schedule stores the event for later delivery. The offset is only committed
+// once the notification is persisted.
+func (h *MessageHandler) schedule(ctx context.Context, logger *logging.Logger, req *notify.Request, sendAt time.Time) error {
+	job, err := h.dispatcher.Schedule(ctx, req, sendAt)
+	if err != nil {
+		logger.Error("failed to schedule message", zap.Error(err))
+		return err
+	}
+	logger.Info("notification scheduled", zap.String("schedule_id", job.ID), zap.Time("send_at", job.DueAt))
+	return nil
+}
 
-	emailPayload := &email.EmailPayload{
-		To:         payload["to"].(string),
-		EMAIL_TYPE: payload["type"].(string),
-		Data:       payload,
+// eventID returns the "event_id" of the event, or else its position in the
+// topic, which stays the same when the event is redelivered
+func eventID(msg kafka.Message, payload map[string]interface{}) string {
+	if id, _ := payload["event_id"].(string); id != "" {
+		return id
 	}
-	log.Println(emailPayload)
-	// h.emailSvc.VerifyEmail(context.Background(), emailPayload)
+	return fmt.Sprintf("%s-%d-%d", msg.Topic, msg.Partition, msg.Offset)
+}
 
+// rateLimited returns the attempt that ended an undelivered outcome on a
+// rate limit, if any
+func rateLimited(outcome *notify.Outcome) *notify.Attempt {
+	if outcome.Delivered() || len(outcome.Attempts) == 0 {
+		return nil
+	}
+	if attempt := &outcome.Attempts[len(outcome.Attempts)-1]; attempt.Status == notify.StatusRateLimited {
+		return attempt
+	}
 	return nil
 }
+
+// retryRateLimited schedules req for when the limit that stopped it resets,
+// so the event is not committed as if it had been handled
+func (h *MessageHandler) retryRateLimited(ctx context.Context, logger *logging.Logger, req *notify.Request, attempt *notify.Attempt) error {
+	logger.Warn("notification rate limited, retrying when the limit resets",
+		zap.String("channel", attempt.Channel),
+		zap.String("reason", attempt.Reason),
+		zap.Duration("retry_after", attempt.RetryAfter),
+	)
+	return h.schedule(ctx, logger, req, time.Now().Add(attempt.RetryAfter))
+}
+
+// parseSendAt accepts an RFC 3339 timestamp or Unix seconds
+func parseSendAt(raw interface{}) (time.Time, error) {
+	switch v := raw.(type) {
+	case string:
+		return time.Parse(time.RFC3339, v)
+	case float64:
+		return time.Unix(int64(v), 0), nil
+	default:
+		return time.Time{}, fmt.Errorf("send_at must be an RFC 3339 timestamp or Unix seconds, got %T", raw)
+	}
+}
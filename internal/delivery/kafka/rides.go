@@ -0,0 +1,167 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+
+	"ride-sharing-notification/internal/pkg/email"
+	"ride-sharing-notification/internal/pkg/logging"
+	"ride-sharing-notification/internal/pkg/notify"
+	"ride-sharing-notification/internal/pkg/receipt"
+	"ride-sharing-notification/internal/pkg/suppression"
+	"ride-sharing-notification/internal/pkg/webhook"
+
+	"github.com/segmentio/kafka-go"
+	"go.uber.org/zap"
+)
+
+// rideEvent is the notification sent for a ride-service event
+type rideEvent struct {
+	notificationType string
+	// recipientField is the payload field holding the recipient's user ID
+	recipientField string
+}
+
+// rideEventTypes maps ride-service event names to notifications
+var rideEventTypes = map[string]rideEvent{
+	"ride.requested":       {"RIDE_REQUESTED", "rider_id"},
+	"ride.driver_assigned": {"DRIVER_ASSIGNED", "rider_id"},
+	"ride.driver_arriving": {"DRIVER_ARRIVING", "rider_id"},
+	"ride.trip_started":    {"TRIP_STARTED", "rider_id"},
+	"ride.trip_completed":  {"TRIP_COMPLETED", "rider_id"},
+	"ride.cancelled":       {"RIDE_CANCELLED", "rider_id"},
+	"payment.failed":       {"PAYMENT_FAILED", "rider_id"},
+
+	"driver.payout_sent":       {"DRIVER_PAYOUT", "driver_id"},
+	"driver.rated":             {"DRIVER_RATING", "driver_id"},
+	"driver.document_expiring": {"DOCUMENT_REMINDER", "driver_id"},
+}
+
+// handleRideEvent notifies the rider or driver about a ride-service event.
+// The recipient is looked up by ID, so delivery follows their preferences
+// and channel order; the event's fields are the template data. Events with
+// "urgent": true skip digests and quiet hours. Events of corporate accounts
+// also go to the account's webhooks, with or without a notification.
+func (h *MessageHandler) handleRideEvent(ctx context.Context, logger *logging.Logger, msg kafka.Message, event string, payload map[string]interface{}) error {
+	if err := h.publishWebhook(ctx, logger, msg, event, payload); err != nil {
+		return err
+	}
+
+	re, ok := rideEventTypes[event]
+	if !ok {
+		logger.Debug("ignoring ride event without notification", zap.String("event", event))
+		return nil
+	}
+	userID, _ := payload[re.recipientField].(string)
+	if userID == "" {
+		logger.Error("dropping ride event without "+re.recipientField, zap.String("event", event))
+		return nil
+	}
+	logger = logger.With(
+		zap.String("event", event),
+		zap.String("type", re.notificationType),
+		zap.String("user_id", userID),
+	)
+
+	if raw, ok := payload["receipt"]; ok && event == "ride.trip_completed" {
+		if err := h.sendReceipt(ctx, logger, userID, raw, payload); err != nil {
+			return err
+		}
+	}
+
+	urgent, _ := payload["urgent"].(bool)
+	req := &notify.Request{
+		ID:        eventID(msg, payload),
+		Recipient: notify.Recipient{UserID: userID},
+		Type:      re.notificationType,
+		Data:      payload,
+		Urgent:    urgent,
+	}
+	outcome, err := h.dispatcher.Send(ctx, req)
+	if err != nil {
+		// Left uncommitted so the event is redelivered
+		logger.Error("failed to dispatch ride notification", zap.Error(err))
+		return err
+	}
+
+	switch {
+	case outcome.OptedOut:
+		logger.Info("user opted out, skipping ride notification")
+	case outcome.Held():
+		logger.Info("ride notification held",
+			zap.String("reason", outcome.HeldReason),
+			zap.Time("held_until", outcome.HeldUntil),
+		)
+	case rateLimited(outcome) != nil:
+		return h.retryRateLimited(ctx, logger, req, rateLimited(outcome))
+	case !outcome.Delivered():
+		logger.Warn("ride notification not delivered on any channel")
+	default:
+		logger.Info("ride notification sent", zap.String("channel", outcome.Channel))
+	}
+	return nil
+}
+
+// publishWebhook queues the event for the webhooks of the corporate
+// account in its "account_id" field. Receivers deduplicate on "event_id".
+// Events without one are identified by their position in the topic, which
+// stays the same when the event is redelivered.
+func (h *MessageHandler) publishWebhook(ctx context.Context, logger *logging.Logger, msg kafka.Message, event string, payload map[string]interface{}) error {
+	accountID, _ := payload["account_id"].(string)
+	if accountID == "" {
+		return nil
+	}
+	queued, err := h.webhooks.Publish(ctx, webhook.Event{
+		ID:        eventID(msg, payload),
+		Type:      event,
+		AccountID: accountID,
+		Data:      payload,
+	})
+	if err != nil {
+		// Left uncommitted so the event is redelivered
+		logger.Error("failed to queue webhook deliveries", zap.String("event", event), zap.Error(err))
+		return err
+	}
+	if queued > 0 {
+		logger.Info("queued webhook deliveries",
+			zap.String("event", event),
+			zap.String("account_id", accountID),
+			zap.Int("deliveries", queued),
+		)
+	}
+	return nil
+}
+
+// sendReceipt emails the receipt of a completed trip. Invalid receipts,
+// riders without an email address and receipts sent before, when the event
+// is redelivered, are logged and skipped; send failures are returned so the
+// event is redelivered.
+func (h *MessageHandler) sendReceipt(ctx context.Context, logger *logging.Logger, riderID string, raw interface{}, payload map[string]interface{}) error {
+	r, err := receipt.FromEvent(raw)
+	if err != nil {
+		logger.Error("skipping invalid receipt", zap.Error(err))
+		return nil
+	}
+	if _, ok := payload["fare"]; !ok {
+		// Lets the trip-completed templates show the total
+		payload["fare"] = receipt.FormatMoney(r.Fare.Total(), r.Currency)
+	}
+	if r.RiderName == "" {
+		r.RiderName, _ = payload["name"].(string)
+	}
+
+	to, _ := payload["email"].(string)
+	err = h.receipts.Send(ctx, riderID, to, r)
+	switch {
+	case err == nil:
+		logger.Info("receipt sent", zap.String("trip_id", r.TripID))
+	case errors.Is(err, receipt.ErrAlreadySent):
+		logger.Info("receipt already sent", zap.String("trip_id", r.TripID))
+	case errors.Is(err, receipt.ErrNoAddress), errors.Is(err, email.ErrDisabled), errors.Is(err, suppression.ErrSuppressed):
+		logger.Info("receipt not sent", zap.Error(err))
+	default:
+		logger.Error("failed to send receipt", zap.Error(err))
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package devicesvc
+
+import (
+	"context"
+	stderrors "errors"
+	"ride-sharing-notification/internal/pkg/auth"
+	"ride-sharing-notification/internal/pkg/devices"
+	"ride-sharing-notification/internal/pkg/errors"
+	"ride-sharing-notification/internal/pkg/response"
+	"ride-sharing-notification/internal/proto/notification"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type Handler struct {
+	devices *devices.Registry
+}
+
+func NewHandler(registry *devices.Registry) *Handler {
+	return &Handler{
+		devices: registry,
+	}
+}
+
+func (h *Handler) RegisterDevice(ctx context.Context, req *notification.RegisterDeviceRequest) (*notification.StandardResponse, error) {
+	userID, err := resolveUser(ctx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	device, err := h.devices.Register(ctx, userID, devices.Device{
+		Token:      req.Token,
+		Platform:   notification.PlatformName(req.Platform),
+		AppVersion: req.AppVersion,
+	})
+	if err != nil {
+		return nil, errors.ToGRPCStatus(appError(err))
+	}
+
+	return response.New().
+		Success().
+		WithMessage("Device registered successfully").
+		WithData(h.toProto(*device), nil)
+}
+
+func (h *Handler) UnregisterDevice(ctx context.Context, req *notification.UnregisterDeviceRequest) (*notification.StandardResponse, error) {
+	userID, err := resolveUser(ctx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	if err := h.devices.Unregister(ctx, userID, req.Token); err != nil {
+		return nil, errors.ToGRPCStatus(errors.NewInternalError(err))
+	}
+
+	return response.New().
+		Success().
+		WithMessage("Device unregistered successfully").
+		SimpleSuccess(), nil
+}
+
+func (h *Handler) ListDevices(ctx context.Context, req *notification.ListDevicesRequest) (*notification.StandardResponse, error) {
+	userID, err := resolveUser(ctx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	list, err := h.devices.List(ctx, userID)
+	if err != nil {
+		return nil, errors.ToGRPCStatus(errors.NewInternalError(err))
+	}
+
+	out := &notification.DeviceList{}
+	for _, device := range list {
+		out.Devices = append(out.Devices, h.toProto(device))
+	}
+	return response.New().
+		Success().
+		WithMessage("Devices retrieved successfully").
+		WithData(out, nil)
+}
+
+func (h *Handler) toProto(d devices.Device) *notification.Device {
+	return &notification.Device{
+		Token:        d.Token,
+		Platform:     notification.PlatformFromName(d.Platform),
+		AppVersion:   d.AppVersion,
+		RegisteredAt: timestamppb.New(d.RegisteredAt),
+		LastSeenAt:   timestamppb.New(d.LastSeen),
+		Active:       h.devices.Active(d),
+	}
+}
+
+// resolveUser returns the user whose devices are accessed
+func resolveUser(ctx context.Context, userID string) (string, error) {
+	userID, appErr := auth.ResolveUser(ctx, userID)
+	if appErr != nil {
+		return "", errors.ToGRPCStatus(appErr)
+	}
+	return userID, nil
+}
+
+// appError passes validation errors through and hides everything else
+func appError(err error) *errors.AppError {
+	var appErr *errors.AppError
+	if stderrors.As(err, &appErr) {
+		return appErr
+	}
+	return errors.NewInternalError(err)
+}
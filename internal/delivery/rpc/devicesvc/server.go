@@ -0,0 +1,33 @@
+package devicesvc
+
+import (
+	"context"
+
+	"ride-sharing-notification/internal/pkg/devices"
+	"ride-sharing-notification/internal/proto/notification"
+)
+
+// DeviceServer implements the device registry methods of
+// NotificationService. It is combined with the channel servers in the rpc
+// package.
+type DeviceServer struct {
+	handler *Handler
+}
+
+func NewDeviceServer(registry *devices.Registry) *DeviceServer {
+	return &DeviceServer{
+		handler: NewHandler(registry),
+	}
+}
+
+func (s *DeviceServer) RegisterDevice(ctx context.Context, req *notification.RegisterDeviceRequest) (*notification.StandardResponse, error) {
+	return s.handler.RegisterDevice(ctx, req)
+}
+
+func (s *DeviceServer) UnregisterDevice(ctx context.Context, req *notification.UnregisterDeviceRequest) (*notification.StandardResponse, error) {
+	return s.handler.UnregisterDevice(ctx, req)
+}
+
+func (s *DeviceServer) ListDevices(ctx context.Context, req *notification.ListDevicesRequest) (*notification.StandardResponse, error) {
+	return s.handler.ListDevices(ctx, req)
+}
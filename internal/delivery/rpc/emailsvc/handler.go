@@ -2,24 +2,36 @@ package emailsvc
 
 import (
 	"context"
+	stderrors "errors"
 	"ride-sharing-notification/internal/pkg/email"
 	"ride-sharing-notification/internal/pkg/errors"
+	"ride-sharing-notification/internal/pkg/logging"
+	"ride-sharing-notification/internal/pkg/ratelimit"
 	"ride-sharing-notification/internal/pkg/response"
 	"ride-sharing-notification/internal/proto/notification"
+
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type Handler struct {
 	emailService *email.Service
+	messages     *email.MessageLog
+	limiter      *ratelimit.Limiter
 }
 
-func NewHandler(emailService *email.Service) *Handler {
-	return &Handler{emailService: emailService}
+func NewHandler(emailService *email.Service, messages *email.MessageLog, limiter *ratelimit.Limiter) *Handler {
+	return &Handler{
+		emailService: emailService,
+		messages:     messages,
+		limiter:      limiter,
+	}
 }
 
 func (h *Handler) SendRegisterEmail(ctx context.Context, req *notification.RegisterEmailRequest) (*notification.StandardResponse, error) {
-	// Validate request
-	if err := validateEmailRequest(req); err != nil {
-		return nil, errors.ToGRPCStatus(err)
+	// Enforce rate limits
+	if appErr := h.checkRateLimit(ctx, req.To, email.EmailTypeRegister); appErr != nil {
+		return nil, errors.ToGRPCStatus(appErr)
 	}
 
 	// Build email payload
@@ -35,8 +47,7 @@ func (h *Handler) SendRegisterEmail(ctx context.Context, req *notification.Regis
 	// Process the email
 	emailResp, err := h.emailService.VerifyEmail(ctx, payload)
 	if err != nil {
-		appErr := errors.NewInternalError(err)
-		return nil, errors.ToGRPCStatus(appErr)
+		return nil, errors.ToGRPCStatus(sendError(err))
 	}
 
 	// Build success response
@@ -51,9 +62,9 @@ func (h *Handler) SendRegisterEmail(ctx context.Context, req *notification.Regis
 }
 
 func (h *Handler) SendForgetPasswordEmail(ctx context.Context, req *notification.ForgetPasswordEmailRequest) (*notification.StandardResponse, error) {
-	// Validate request
-	if err := validateEmailRequest(req); err != nil {
-		return nil, errors.ToGRPCStatus(err)
+	// Enforce rate limits
+	if appErr := h.checkRateLimit(ctx, req.To, email.EmailTypeForgetPassword); appErr != nil {
+		return nil, errors.ToGRPCStatus(appErr)
 	}
 
 	// Build email payload
@@ -69,8 +80,7 @@ func (h *Handler) SendForgetPasswordEmail(ctx context.Context, req *notification
 	// Process the email
 	emailResp, err := h.emailService.VerifyEmail(ctx, payload)
 	if err != nil {
-		appErr := errors.NewInternalError(err)
-		return nil, errors.ToGRPCStatus(appErr)
+		return nil, errors.ToGRPCStatus(sendError(err))
 	}
 
 	// Build success response
@@ -83,3 +93,56 @@ func (h *Handler) SendForgetPasswordEmail(ctx context.Context, req *notification
 	}
 	return respBuilder.SimpleSuccess(), nil
 }
+
+// GetEmailStatus reports whether a recently sent email bounced or drew a
+// complaint
+func (h *Handler) GetEmailStatus(ctx context.Context, req *notification.GetEmailStatusRequest) (*notification.StandardResponse, error) {
+	m, err := h.messages.Get(ctx, req.MessageId)
+	if stderrors.Is(err, email.ErrMessageNotFound) {
+		return nil, errors.ToGRPCStatus(errors.NewNotFoundError(err.Error()))
+	}
+	if err != nil {
+		return nil, errors.ToGRPCStatus(errors.NewInternalError(err))
+	}
+
+	return response.New().
+		Success().
+		WithMessage("Email status retrieved successfully").
+		WithData(&notification.EmailStatus{
+			MessageId: m.ID,
+			To:        m.To,
+			Type:      m.Type,
+			Status:    m.Status,
+			Reason:    m.Reason,
+			SentAt:    timestamppb.New(m.SentAt),
+			UpdatedAt: timestamppb.New(m.UpdatedAt),
+		}, nil)
+}
+
+// sendError maps a send failure to the error returned to the caller
+func sendError(err error) *errors.AppError {
+	// Suppressed recipients already come back as *errors.AppError
+	var appErr *errors.AppError
+	if stderrors.As(err, &appErr) {
+		return appErr
+	}
+	if stderrors.Is(err, email.ErrDisabled) {
+		return errors.NewUnavailableError(err.Error())
+	}
+	return errors.NewInternalError(err)
+}
+
+// checkRateLimit counts the send against the configured limits
+func (h *Handler) checkRateLimit(ctx context.Context, to, emailType string) *errors.AppError {
+	appErr, err := h.limiter.Check(ctx, ratelimit.Subject{
+		Recipient: to,
+		Type:      emailType,
+		Caller:    logging.CallerFromContext(ctx),
+	})
+	if err != nil {
+		logging.GetLogger().WithContext(ctx).Warn("rate limit check failed, allowing request",
+			zap.Error(err),
+		)
+	}
+	return appErr
+}
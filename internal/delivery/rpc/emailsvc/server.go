@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"ride-sharing-notification/internal/pkg/email"
+	"ride-sharing-notification/internal/pkg/ratelimit"
 	"ride-sharing-notification/internal/proto/notification"
 
 	"google.golang.org/grpc"
@@ -14,14 +15,14 @@ type EmailServer struct {
 	handler *Handler
 }
 
-func NewEmailServer(emailService *email.Service) *EmailServer {
+func NewEmailServer(emailService *email.Service, messages *email.MessageLog, limiter *ratelimit.Limiter) *EmailServer {
 	return &EmailServer{
-		handler: NewHandler(emailService),
+		handler: NewHandler(emailService, messages, limiter),
 	}
 }
 
-func Register(server *grpc.Server, emailService *email.Service) {
-	notification.RegisterNotificationServiceServer(server, NewEmailServer(emailService))
+func Register(server *grpc.Server, emailService *email.Service, messages *email.MessageLog, limiter *ratelimit.Limiter) {
+	notification.RegisterNotificationServiceServer(server, NewEmailServer(emailService, messages, limiter))
 }
 
 func (s *EmailServer) SendRegisterEmail(ctx context.Context, req *notification.RegisterEmailRequest) (*notification.StandardResponse, error) {
@@ -31,3 +32,7 @@ func (s *EmailServer) SendRegisterEmail(ctx context.Context, req *notification.R
 func (s *EmailServer) SendForgetPasswordEmail(ctx context.Context, req *notification.ForgetPasswordEmailRequest) (*notification.StandardResponse, error) {
 	return s.handler.SendForgetPasswordEmail(ctx, req)
 }
+
+func (s *EmailServer) GetEmailStatus(ctx context.Context, req *notification.GetEmailStatusRequest) (*notification.StandardResponse, error) {
+	return s.handler.GetEmailStatus(ctx, req)
+}
@@ -0,0 +1,156 @@
+package inboxsvc
+
+import (
+	"context"
+	"time"
+
+	"ride-sharing-notification/internal/pkg/auth"
+	"ride-sharing-notification/internal/pkg/errors"
+	"ride-sharing-notification/internal/pkg/inbox"
+	"ride-sharing-notification/internal/pkg/response"
+	"ride-sharing-notification/internal/proto/notification"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const defaultPerPage = 20
+
+var filters = map[notification.InboxFilter]string{
+	notification.InboxFilter_INBOX_FILTER_UNSPECIFIED: inbox.FilterAll,
+	notification.InboxFilter_INBOX_FILTER_UNREAD:      inbox.FilterUnread,
+	notification.InboxFilter_INBOX_FILTER_ARCHIVED:    inbox.FilterArchived,
+}
+
+var actions = map[notification.InboxAction]string{
+	notification.InboxAction_INBOX_ACTION_MARK_READ:   inbox.ActionRead,
+	notification.InboxAction_INBOX_ACTION_MARK_UNREAD: inbox.ActionUnread,
+	notification.InboxAction_INBOX_ACTION_ARCHIVE:     inbox.ActionArchive,
+	notification.InboxAction_INBOX_ACTION_UNARCHIVE:   inbox.ActionUnarchive,
+}
+
+type Handler struct {
+	inbox *inbox.Service
+}
+
+func NewHandler(inboxSvc *inbox.Service) *Handler {
+	return &Handler{
+		inbox: inboxSvc,
+	}
+}
+
+func (h *Handler) ListInbox(ctx context.Context, req *notification.ListInboxRequest) (*notification.StandardResponse, error) {
+	userID, err := resolveUser(ctx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	page := max(int(req.Page), 1)
+	perPage := int(req.PerPage)
+	if perPage == 0 {
+		perPage = defaultPerPage
+	}
+
+	items, total, err := h.inbox.List(ctx, userID, filters[req.Filter], page, perPage)
+	if err != nil {
+		return nil, errors.ToGRPCStatus(errors.NewInternalError(err))
+	}
+	unread, err := h.inbox.UnreadCount(ctx, userID)
+	if err != nil {
+		return nil, errors.ToGRPCStatus(errors.NewInternalError(err))
+	}
+
+	out := &notification.InboxList{UnreadCount: int32(unread)}
+	for i := range items {
+		out.Items = append(out.Items, toProto(&items[i]))
+	}
+	return response.New().
+		Success().
+		WithMessage("Inbox retrieved successfully").
+		WithData(out, &notification.MetaData{
+			Page:    int32(page),
+			PerPage: int32(perPage),
+			Total:   int32(total),
+		})
+}
+
+func (h *Handler) UpdateInboxItems(ctx context.Context, req *notification.UpdateInboxItemsRequest) (*notification.StandardResponse, error) {
+	userID, err := resolveUser(ctx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	updated, err := h.inbox.Update(ctx, userID, req.Ids, actions[req.Action])
+	if err != nil {
+		return nil, errors.ToGRPCStatus(errors.NewInternalError(err))
+	}
+	return h.updated(ctx, userID, updated)
+}
+
+func (h *Handler) MarkAllInboxRead(ctx context.Context, req *notification.MarkAllInboxReadRequest) (*notification.StandardResponse, error) {
+	userID, err := resolveUser(ctx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	updated, err := h.inbox.MarkAllRead(ctx, userID)
+	if err != nil {
+		return nil, errors.ToGRPCStatus(errors.NewInternalError(err))
+	}
+	return h.updated(ctx, userID, updated)
+}
+
+func (h *Handler) GetUnreadCount(ctx context.Context, req *notification.GetUnreadCountRequest) (*notification.StandardResponse, error) {
+	userID, err := resolveUser(ctx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	unread, err := h.inbox.UnreadCount(ctx, userID)
+	if err != nil {
+		return nil, errors.ToGRPCStatus(errors.NewInternalError(err))
+	}
+	return response.New().
+		Success().
+		WithMessage("Unread count retrieved successfully").
+		WithData(&notification.UnreadCount{Count: int32(unread)}, nil)
+}
+
+// updated reports a change with the unread count after it
+func (h *Handler) updated(ctx context.Context, userID string, updated int) (*notification.StandardResponse, error) {
+	unread, err := h.inbox.UnreadCount(ctx, userID)
+	if err != nil {
+		return nil, errors.ToGRPCStatus(errors.NewInternalError(err))
+	}
+	return response.New().
+		Success().
+		WithMessage("Inbox updated successfully").
+		WithData(&notification.InboxUpdateResult{
+			Updated:     int32(updated),
+			UnreadCount: int32(unread),
+		}, nil)
+}
+
+// resolveUser returns the user whose inbox is accessed
+func resolveUser(ctx context.Context, userID string) (string, error) {
+	userID, appErr := auth.ResolveUser(ctx, userID)
+	if appErr != nil {
+		return "", errors.ToGRPCStatus(appErr)
+	}
+	return userID, nil
+}
+
+func toProto(item *inbox.Item) *notification.InboxItem {
+	return &notification.InboxItem{
+		Id:         item.ID,
+		Type:       item.Type,
+		Title:      item.Title,
+		Body:       item.Body,
+		Data:       item.Data,
+		CreatedAt:  timestamppb.New(item.CreatedAt),
+		ReadAt:     timestamp(item.ReadAt),
+		ArchivedAt: timestamp(item.ArchivedAt),
+	}
+}
+
+func timestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
@@ -0,0 +1,36 @@
+package inboxsvc
+
+import (
+	"context"
+
+	"ride-sharing-notification/internal/pkg/inbox"
+	"ride-sharing-notification/internal/proto/notification"
+)
+
+// InboxServer implements the in-app inbox methods of NotificationService.
+// It is combined with the channel servers in the rpc package.
+type InboxServer struct {
+	handler *Handler
+}
+
+func NewInboxServer(inboxSvc *inbox.Service) *InboxServer {
+	return &InboxServer{
+		handler: NewHandler(inboxSvc),
+	}
+}
+
+func (s *InboxServer) ListInbox(ctx context.Context, req *notification.ListInboxRequest) (*notification.StandardResponse, error) {
+	return s.handler.ListInbox(ctx, req)
+}
+
+func (s *InboxServer) UpdateInboxItems(ctx context.Context, req *notification.UpdateInboxItemsRequest) (*notification.StandardResponse, error) {
+	return s.handler.UpdateInboxItems(ctx, req)
+}
+
+func (s *InboxServer) MarkAllInboxRead(ctx context.Context, req *notification.MarkAllInboxReadRequest) (*notification.StandardResponse, error) {
+	return s.handler.MarkAllInboxRead(ctx, req)
+}
+
+func (s *InboxServer) GetUnreadCount(ctx context.Context, req *notification.GetUnreadCountRequest) (*notification.StandardResponse, error) {
+	return s.handler.GetUnreadCount(ctx, req)
+}
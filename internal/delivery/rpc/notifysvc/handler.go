@@ -0,0 +1,186 @@
+package notifysvc
+
+import (
+	"context"
+	stderrors "errors"
+	"ride-sharing-notification/internal/pkg/errors"
+	"ride-sharing-notification/internal/pkg/notify"
+	"ride-sharing-notification/internal/pkg/preferences"
+	"ride-sharing-notification/internal/pkg/quiethours"
+	"ride-sharing-notification/internal/pkg/response"
+	"ride-sharing-notification/internal/proto/notification"
+	"strings"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type Handler struct {
+	dispatcher *notify.Dispatcher
+	scheduler  *notify.Scheduler
+}
+
+func NewHandler(dispatcher *notify.Dispatcher, scheduler *notify.Scheduler) *Handler {
+	return &Handler{
+		dispatcher: dispatcher,
+		scheduler:  scheduler,
+	}
+}
+
+func (h *Handler) SendNotification(ctx context.Context, req *notification.NotificationRequest) (*notification.StandardResponse, error) {
+	request, appErr := toRequest(req)
+	if appErr != nil {
+		return nil, errors.ToGRPCStatus(appErr)
+	}
+	outcome, err := h.dispatcher.Send(ctx, request)
+	if stderrors.Is(err, notify.ErrUnavailable) {
+		return nil, errors.ToGRPCStatus(errors.NewUnavailableError(err.Error()))
+	}
+	if err != nil {
+		return nil, errors.ToGRPCStatus(errors.NewInternalError(err))
+	}
+	if outcome.OptedOut {
+		return nil, errors.ToGRPCStatus(errors.NewForbiddenError(
+			"user has opted out of " + string(preferences.CategoryOf(req.Type)) + " notifications",
+		))
+	}
+
+	if outcome.Held() {
+		message := "Notification held until quiet hours end"
+		if outcome.HeldReason == notify.ReasonDigest {
+			message = "Notification added to digest"
+		}
+		return response.New().
+			Success().
+			WithMessage(message).
+			WithData(&notification.NotificationResult{
+				HeldUntil:  timestamppb.New(outcome.HeldUntil),
+				ScheduleId: outcome.ScheduleID,
+				HeldReason: outcome.HeldReason,
+			}, nil)
+	}
+
+	result := &notification.NotificationResult{
+		Delivered: outcome.Delivered(),
+		Channel:   notification.ChannelFromName(outcome.Channel),
+		MessageId: outcome.MessageID,
+	}
+	reasons := make([]string, 0, len(outcome.Attempts))
+	for _, attempt := range outcome.Attempts {
+		result.Attempts = append(result.Attempts, &notification.ChannelAttempt{
+			Channel: notification.ChannelFromName(attempt.Channel),
+			Status:  attempt.Status,
+			Reason:  attempt.Reason,
+		})
+		reasons = append(reasons, attempt.Channel+": "+attempt.Reason)
+	}
+
+	if !outcome.Delivered() {
+		return nil, errors.ToGRPCStatus(errors.NewUnavailableError(
+			"notification not delivered on any channel (" + strings.Join(reasons, "; ") + ")",
+		))
+	}
+
+	return response.New().
+		Success().
+		WithMessage("Notification sent via "+outcome.Channel).
+		WithData(result, nil)
+}
+
+func (h *Handler) ScheduleNotification(ctx context.Context, req *notification.ScheduleNotificationRequest) (*notification.StandardResponse, error) {
+	request, appErr := toRequest(req.Notification)
+	if appErr != nil {
+		return nil, errors.ToGRPCStatus(appErr)
+	}
+	job, err := h.dispatcher.Schedule(ctx, request, req.SendAt.AsTime())
+	if err != nil {
+		return nil, errors.ToGRPCStatus(errors.NewInternalError(err))
+	}
+
+	return response.New().
+		Success().
+		WithMessage("Notification scheduled successfully").
+		WithData(toScheduled(job), nil)
+}
+
+func (h *Handler) CancelScheduledNotification(ctx context.Context, req *notification.CancelScheduledNotificationRequest) (*notification.StandardResponse, error) {
+	if err := h.scheduler.Cancel(ctx, req.Id); err != nil {
+		return nil, errors.ToGRPCStatus(scheduleError(err))
+	}
+
+	return response.New().
+		Success().
+		WithMessage("Scheduled notification cancelled").
+		SimpleSuccess(), nil
+}
+
+func (h *Handler) RescheduleNotification(ctx context.Context, req *notification.RescheduleNotificationRequest) (*notification.StandardResponse, error) {
+	job, err := h.scheduler.Reschedule(ctx, req.Id, req.SendAt.AsTime())
+	if err != nil {
+		return nil, errors.ToGRPCStatus(scheduleError(err))
+	}
+
+	return response.New().
+		Success().
+		WithMessage("Notification rescheduled successfully").
+		WithData(toScheduled(job), nil)
+}
+
+// toRequest converts a validated request for the dispatcher. Quiet hours
+// are parsed here, so invalid ones are returned as a validation error.
+func toRequest(req *notification.NotificationRequest) (*notify.Request, *errors.AppError) {
+	channels := make([]string, 0, len(req.Channels))
+	for _, channel := range req.Channels {
+		channels = append(channels, notification.ChannelName(channel))
+	}
+
+	data := make(map[string]interface{}, len(req.Data))
+	for k, v := range req.Data {
+		data[k] = v
+	}
+
+	var quietHours *quiethours.Window
+	if q := req.GetQuietHours(); q != nil {
+		window, err := quiethours.Parse(q.Start, q.End)
+		if err != nil {
+			return nil, errors.NewValidationError("invalid request", map[string]string{
+				"quiet_hours": err.Error(),
+			})
+		}
+		quietHours = &window
+	}
+
+	recipient := req.GetRecipient()
+	return &notify.Request{
+		Recipient: notify.Recipient{
+			UserID:       recipient.GetUserId(),
+			Email:        recipient.GetEmail(),
+			Phone:        recipient.GetPhone(),
+			DeviceTokens: recipient.GetDeviceTokens(),
+		},
+		Type:       req.Type,
+		Data:       data,
+		Channels:   channels,
+		TimeZone:   req.TimeZone,
+		QuietHours: quietHours,
+		Urgent:     req.Urgent,
+	}, nil
+}
+
+func toScheduled(job *notify.Job) *notification.ScheduledNotification {
+	return &notification.ScheduledNotification{
+		Id:        job.ID,
+		SendAt:    timestamppb.New(job.DueAt),
+		Type:      job.Request.Type,
+		Reason:    job.Reason,
+		CreatedAt: timestamppb.New(job.CreatedAt),
+		Attempts:  int32(job.Attempts),
+	}
+}
+
+// scheduleError maps a scheduler failure to the error returned to the caller
+func scheduleError(err error) *errors.AppError {
+	if stderrors.Is(err, notify.ErrJobNotFound) {
+		return errors.NewNotFoundError(err.Error())
+	}
+	return errors.NewInternalError(err)
+}
@@ -0,0 +1,37 @@
+package notifysvc
+
+import (
+	"context"
+
+	"ride-sharing-notification/internal/pkg/notify"
+	"ride-sharing-notification/internal/proto/notification"
+)
+
+// NotifyServer implements the channel-agnostic methods of
+// NotificationService. It is combined with the channel servers in the rpc
+// package.
+type NotifyServer struct {
+	handler *Handler
+}
+
+func NewNotifyServer(dispatcher *notify.Dispatcher, scheduler *notify.Scheduler) *NotifyServer {
+	return &NotifyServer{
+		handler: NewHandler(dispatcher, scheduler),
+	}
+}
+
+func (s *NotifyServer) SendNotification(ctx context.Context, req *notification.NotificationRequest) (*notification.StandardResponse, error) {
+	return s.handler.SendNotification(ctx, req)
+}
+
+func (s *NotifyServer) ScheduleNotification(ctx context.Context, req *notification.ScheduleNotificationRequest) (*notification.StandardResponse, error) {
+	return s.handler.ScheduleNotification(ctx, req)
+}
+
+func (s *NotifyServer) CancelScheduledNotification(ctx context.Context, req *notification.CancelScheduledNotificationRequest) (*notification.StandardResponse, error) {
+	return s.handler.CancelScheduledNotification(ctx, req)
+}
+
+func (s *NotifyServer) RescheduleNotification(ctx context.Context, req *notification.RescheduleNotificationRequest) (*notification.StandardResponse, error) {
+	return s.handler.RescheduleNotification(ctx, req)
+}
@@ -0,0 +1,141 @@
+package prefsvc
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"ride-sharing-notification/internal/pkg/auth"
+	"ride-sharing-notification/internal/pkg/errors"
+	"ride-sharing-notification/internal/pkg/preferences"
+	"ride-sharing-notification/internal/pkg/response"
+	"ride-sharing-notification/internal/proto/notification"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type Handler struct {
+	preferences *preferences.Service
+}
+
+func NewHandler(preferencesService *preferences.Service) *Handler {
+	return &Handler{
+		preferences: preferencesService,
+	}
+}
+
+func (h *Handler) GetPreferences(ctx context.Context, req *notification.GetPreferencesRequest) (*notification.StandardResponse, error) {
+	userID, err := resolveUser(ctx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	prefs, err := h.preferences.Get(ctx, userID)
+	if err != nil {
+		return nil, errors.ToGRPCStatus(errors.NewInternalError(err))
+	}
+
+	return response.New().
+		Success().
+		WithMessage("Preferences retrieved successfully").
+		WithData(toProto(prefs), nil)
+}
+
+func (h *Handler) UpdatePreferences(ctx context.Context, req *notification.UpdatePreferencesRequest) (*notification.StandardResponse, error) {
+	paths := req.GetUpdateMask().GetPaths()
+	if len(paths) == 0 {
+		paths = []string{"emails", "phones", "device_tokens", "categories", "channels", "locale", "time_zone", "quiet_hours"}
+	}
+
+	in := req.GetPreferences()
+	userID, err := resolveUser(ctx, in.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+	prefs, err := h.preferences.Update(ctx, userID, func(p *preferences.Preferences) error {
+		for _, path := range paths {
+			switch path {
+			case "emails":
+				p.Emails = in.GetEmails()
+			case "phones":
+				p.Phones = in.GetPhones()
+			case "device_tokens":
+				p.DeviceTokens = in.GetDeviceTokens()
+			case "categories":
+				for category, optedIn := range in.GetCategories() {
+					p.Categories[preferences.Category(category)] = optedIn
+				}
+			case "channels":
+				p.Channels = p.Channels[:0]
+				for _, channel := range in.GetChannels() {
+					name := notification.ChannelName(channel)
+					if name == "" {
+						return errors.NewValidationError("invalid preferences", map[string]string{
+							"channels": "channel must be specified",
+						})
+					}
+					p.Channels = append(p.Channels, name)
+				}
+			case "locale":
+				p.Locale = in.GetLocale()
+			case "time_zone":
+				p.TimeZone = in.GetTimeZone()
+			case "quiet_hours":
+				// Unset quiet hours fall back to the service default
+				p.QuietHours = nil
+				if q := in.GetQuietHours(); q != nil {
+					p.QuietHours = &preferences.QuietHours{Start: q.Start, End: q.End}
+				}
+			default:
+				return errors.NewValidationError("invalid update mask", map[string]string{
+					"update_mask": fmt.Sprintf("unknown field %q", path),
+				})
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		var appErr *errors.AppError
+		if stderrors.As(err, &appErr) {
+			return nil, errors.ToGRPCStatus(appErr)
+		}
+		return nil, errors.ToGRPCStatus(errors.NewInternalError(err))
+	}
+
+	return response.New().
+		Success().
+		WithMessage("Preferences updated successfully").
+		WithData(toProto(prefs), nil)
+}
+
+// resolveUser returns the user whose preferences are accessed
+func resolveUser(ctx context.Context, userID string) (string, error) {
+	userID, appErr := auth.ResolveUser(ctx, userID)
+	if appErr != nil {
+		return "", errors.ToGRPCStatus(appErr)
+	}
+	return userID, nil
+}
+
+func toProto(p *preferences.Preferences) *notification.UserPreferences {
+	out := &notification.UserPreferences{
+		UserId:       p.UserID,
+		Emails:       p.Emails,
+		Phones:       p.Phones,
+		DeviceTokens: p.DeviceTokens,
+		Categories:   make(map[string]bool, len(preferences.Categories)),
+		Locale:       p.Locale,
+		TimeZone:     p.TimeZone,
+	}
+	if p.QuietHours != nil {
+		out.QuietHours = &notification.QuietHours{Start: p.QuietHours.Start, End: p.QuietHours.End}
+	}
+	for _, category := range preferences.Categories {
+		out.Categories[string(category)] = p.OptedIn(category)
+	}
+	for _, channel := range p.Channels {
+		out.Channels = append(out.Channels, notification.ChannelFromName(channel))
+	}
+	if !p.UpdatedAt.IsZero() {
+		out.UpdatedAt = timestamppb.New(p.UpdatedAt)
+	}
+	return out
+}
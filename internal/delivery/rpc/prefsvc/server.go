@@ -0,0 +1,29 @@
+package prefsvc
+
+import (
+	"context"
+
+	"ride-sharing-notification/internal/pkg/preferences"
+	"ride-sharing-notification/internal/proto/notification"
+)
+
+// PreferencesServer implements the preference methods of
+// NotificationService. It is combined with the channel servers in the rpc
+// package.
+type PreferencesServer struct {
+	handler *Handler
+}
+
+func NewPreferencesServer(preferencesService *preferences.Service) *PreferencesServer {
+	return &PreferencesServer{
+		handler: NewHandler(preferencesService),
+	}
+}
+
+func (s *PreferencesServer) GetPreferences(ctx context.Context, req *notification.GetPreferencesRequest) (*notification.StandardResponse, error) {
+	return s.handler.GetPreferences(ctx, req)
+}
+
+func (s *PreferencesServer) UpdatePreferences(ctx context.Context, req *notification.UpdatePreferencesRequest) (*notification.StandardResponse, error) {
+	return s.handler.UpdatePreferences(ctx, req)
+}
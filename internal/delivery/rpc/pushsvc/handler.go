@@ -0,0 +1,121 @@
+package pushsvc
+
+import (
+	"context"
+	stderrors "errors"
+	"ride-sharing-notification/internal/pkg/devices"
+	"ride-sharing-notification/internal/pkg/errors"
+	"ride-sharing-notification/internal/pkg/logging"
+	"ride-sharing-notification/internal/pkg/push"
+	"ride-sharing-notification/internal/pkg/ratelimit"
+	"ride-sharing-notification/internal/pkg/response"
+	"ride-sharing-notification/internal/proto/notification"
+
+	"go.uber.org/zap"
+)
+
+// pushTypeCustom is the rate limit type for pushes with caller supplied text
+const pushTypeCustom = "CUSTOM_PUSH"
+
+type Handler struct {
+	pushService *push.Service
+	devices     *devices.Registry
+	limiter     *ratelimit.Limiter
+}
+
+func NewHandler(pushService *push.Service, registry *devices.Registry, limiter *ratelimit.Limiter) *Handler {
+	return &Handler{
+		pushService: pushService,
+		devices:     registry,
+		limiter:     limiter,
+	}
+}
+
+func (h *Handler) SendPush(ctx context.Context, req *notification.PushRequest) (*notification.StandardResponse, error) {
+	// Users are limited as a whole rather than per device
+	recipient := req.DeviceToken
+	if req.UserId != "" {
+		recipient = req.UserId
+	}
+
+	// Enforce rate limits
+	if appErr := h.checkRateLimit(ctx, recipient); appErr != nil {
+		return nil, errors.ToGRPCStatus(appErr)
+	}
+
+	tokens := []string{req.DeviceToken}
+	if req.UserId != "" {
+		var err error
+		if tokens, err = h.devices.Tokens(ctx, req.UserId); err != nil {
+			return nil, errors.ToGRPCStatus(errors.NewInternalError(err))
+		}
+	}
+
+	data := make(map[string]interface{}, len(req.Data))
+	for k, v := range req.Data {
+		data[k] = v
+	}
+
+	result, err := h.pushService.Send(ctx, &push.Payload{
+		Tokens: tokens,
+		Type:   pushTypeCustom,
+		Title:  req.Title,
+		Body:   req.Body,
+		Data:   data,
+	})
+	if err != nil {
+		return nil, errors.ToGRPCStatus(sendError(err))
+	}
+
+	unregistered := 0
+	for _, failure := range result.Failed {
+		if failure.Unregistered {
+			unregistered++
+		}
+	}
+
+	return response.New().
+		Success().
+		WithMessage("Push notification sent successfully").
+		WithData(&notification.PushResult{
+			Provider:     result.Provider,
+			Delivered:    int32(len(result.Delivered)),
+			Failed:       int32(len(result.Failed)),
+			Unregistered: int32(unregistered),
+		}, nil)
+}
+
+// sendError maps a send failure to the error returned to the caller
+func sendError(err error) *errors.AppError {
+	// Suppressed recipients already come back as *errors.AppError
+	var appErr *errors.AppError
+	if stderrors.As(err, &appErr) {
+		return appErr
+	}
+	switch {
+	case stderrors.Is(err, push.ErrDisabled):
+		return errors.NewUnavailableError(err.Error())
+	case stderrors.Is(err, push.ErrNoDevices):
+		return errors.NewNotFoundError("user has no active devices")
+	case push.Permanent(err):
+		return errors.NewValidationError("push rejected by provider", map[string]string{
+			"device_token": err.Error(),
+		})
+	}
+	return errors.NewInternalError(err)
+}
+
+// checkRateLimit counts the send against the configured limits
+func (h *Handler) checkRateLimit(ctx context.Context, recipient string) *errors.AppError {
+	appErr, err := h.limiter.Check(ctx, ratelimit.Subject{
+		Recipient: recipient,
+		Type:      pushTypeCustom,
+		Caller:    logging.CallerFromContext(ctx),
+	})
+	if err != nil {
+		logging.GetLogger().WithContext(ctx).Warn("rate limit check failed, allowing request",
+			zap.Error(err),
+		)
+	}
+	return appErr
+}
@@ -0,0 +1,26 @@
+package pushsvc
+
+import (
+	"context"
+
+	"ride-sharing-notification/internal/pkg/devices"
+	"ride-sharing-notification/internal/pkg/push"
+	"ride-sharing-notification/internal/pkg/ratelimit"
+	"ride-sharing-notification/internal/proto/notification"
+)
+
+// PushServer implements the push methods of NotificationService. It is
+// combined with the other channel servers in the rpc package.
+type PushServer struct {
+	handler *Handler
+}
+
+func NewPushServer(pushService *push.Service, registry *devices.Registry, limiter *ratelimit.Limiter) *PushServer {
+	return &PushServer{
+		handler: NewHandler(pushService, registry, limiter),
+	}
+}
+
+func (s *PushServer) SendPush(ctx context.Context, req *notification.PushRequest) (*notification.StandardResponse, error) {
+	return s.handler.SendPush(ctx, req)
+}
@@ -0,0 +1,108 @@
+package realtimesvc
+
+import (
+	"time"
+
+	"ride-sharing-notification/internal/pkg/auth"
+	"ride-sharing-notification/internal/pkg/errors"
+	"ride-sharing-notification/internal/pkg/logging"
+	"ride-sharing-notification/internal/pkg/realtime"
+	"ride-sharing-notification/internal/proto/notification"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type Handler struct {
+	hub *realtime.Hub
+}
+
+func NewHandler(hub *realtime.Hub) *Handler {
+	return &Handler{
+		hub: hub,
+	}
+}
+
+// SubscribeNotifications replays what the app missed since its cursor, if
+// it sent one, and then streams new notifications until the client
+// disconnects. Idle streams get a heartbeat every interval.
+func (h *Handler) SubscribeNotifications(req *notification.SubscribeNotificationsRequest, stream grpc.ServerStreamingServer[notification.NotificationEvent]) error {
+	ctx := stream.Context()
+	userID, appErr := auth.ResolveUser(ctx, req.UserId)
+	if appErr != nil {
+		return errors.ToGRPCStatus(appErr)
+	}
+	// Validated by the interceptor
+	last, _ := realtime.ParseCursor(req.Cursor)
+
+	logger := logging.GetLogger().WithContext(ctx).With(zap.String("user_id", userID))
+	logger.Info("in-app stream opened", zap.String("cursor", req.Cursor))
+	defer logger.Info("in-app stream closed")
+
+	// Subscribe before replaying so nothing published in between is missed
+	sub := h.hub.Subscribe(userID)
+	defer sub.Close()
+
+	// Without a cursor the app is new or starting over and only gets live
+	// notifications
+	var missed []realtime.Event
+	if req.Cursor != "" {
+		var err error
+		if missed, err = h.hub.Replay(ctx, userID, last); err != nil {
+			return errors.ToGRPCStatus(errors.NewInternalError(err))
+		}
+	}
+	for i := range missed {
+		if err := stream.Send(toEvent(&missed[i])); err != nil {
+			return err
+		}
+		last = missed[i].ID
+	}
+
+	heartbeat := time.NewTicker(h.hub.Heartbeat())
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case e, ok := <-sub.Events():
+			if !ok {
+				return errors.ToGRPCStatus(errors.NewUnavailableError("stream closed, reconnect with the last cursor"))
+			}
+			if e.ID <= last {
+				// Already sent while replaying
+				continue
+			}
+			if err := stream.Send(toEvent(&e)); err != nil {
+				return err
+			}
+			last = e.ID
+			heartbeat.Reset(h.hub.Heartbeat())
+		case t := <-heartbeat.C:
+			if err := stream.Send(&notification.NotificationEvent{
+				Event: &notification.NotificationEvent_Heartbeat{
+					Heartbeat: &notification.Heartbeat{Time: timestamppb.New(t)},
+				},
+			}); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func toEvent(e *realtime.Event) *notification.NotificationEvent {
+	return &notification.NotificationEvent{
+		Event: &notification.NotificationEvent_Notification{
+			Notification: &notification.InAppNotification{
+				Id:        e.Cursor(),
+				Type:      e.Type,
+				Title:     e.Title,
+				Body:      e.Body,
+				Data:      e.Data,
+				CreatedAt: timestamppb.New(e.CreatedAt),
+				InboxId:   e.InboxID,
+			},
+		},
+	}
+}
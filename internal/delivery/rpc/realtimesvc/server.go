@@ -0,0 +1,25 @@
+package realtimesvc
+
+import (
+	"ride-sharing-notification/internal/pkg/realtime"
+	"ride-sharing-notification/internal/proto/notification"
+
+	"google.golang.org/grpc"
+)
+
+// RealtimeServer implements the in-app notification stream of
+// NotificationService. It is combined with the channel servers in the rpc
+// package.
+type RealtimeServer struct {
+	handler *Handler
+}
+
+func NewRealtimeServer(hub *realtime.Hub) *RealtimeServer {
+	return &RealtimeServer{
+		handler: NewHandler(hub),
+	}
+}
+
+func (s *RealtimeServer) SubscribeNotifications(req *notification.SubscribeNotificationsRequest, stream grpc.ServerStreamingServer[notification.NotificationEvent]) error {
+	return s.handler.SubscribeNotifications(req, stream)
+}
@@ -2,49 +2,148 @@ package rpc
 
 import (
 	"context"
+	"crypto/tls"
 	"net"
 	"time"
 
+	"ride-sharing-notification/internal/delivery/rpc/devicesvc"
 	"ride-sharing-notification/internal/delivery/rpc/emailsvc"
+	"ride-sharing-notification/internal/delivery/rpc/inboxsvc"
+	"ride-sharing-notification/internal/delivery/rpc/notifysvc"
+	"ride-sharing-notification/internal/delivery/rpc/prefsvc"
+	"ride-sharing-notification/internal/delivery/rpc/pushsvc"
+	"ride-sharing-notification/internal/delivery/rpc/realtimesvc"
+	"ride-sharing-notification/internal/delivery/rpc/smssvc"
+	"ride-sharing-notification/internal/delivery/rpc/suppressionsvc"
+	"ride-sharing-notification/internal/delivery/rpc/webhooksvc"
+	"ride-sharing-notification/internal/pkg/auth"
+	"ride-sharing-notification/internal/pkg/devices"
 	"ride-sharing-notification/internal/pkg/email"
+	"ride-sharing-notification/internal/pkg/inbox"
 	"ride-sharing-notification/internal/pkg/logging"
 	"ride-sharing-notification/internal/pkg/middleware"
+	"ride-sharing-notification/internal/pkg/notify"
+	"ride-sharing-notification/internal/pkg/preferences"
+	"ride-sharing-notification/internal/pkg/push"
+	"ride-sharing-notification/internal/pkg/ratelimit"
+	"ride-sharing-notification/internal/pkg/realtime"
+	"ride-sharing-notification/internal/pkg/sms"
+	"ride-sharing-notification/internal/pkg/suppression"
+	"ride-sharing-notification/internal/pkg/webhook"
 	"ride-sharing-notification/internal/proto/notification"
 
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/health"
 	"google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
 )
 
+// notificationServer combines the channel servers into one
+// NotificationService implementation
+type notificationServer struct {
+	*emailsvc.EmailServer
+	*smssvc.SMSServer
+	*pushsvc.PushServer
+	*notifysvc.NotifyServer
+	*prefsvc.PreferencesServer
+	*devicesvc.DeviceServer
+	*realtimesvc.RealtimeServer
+	*inboxsvc.InboxServer
+	*webhooksvc.WebhookServer
+	*suppressionsvc.SuppressionServer
+}
+
+// Services are the application services exposed over gRPC
+type Services struct {
+	Email *email.Service
+	// EmailMessages are the recently sent emails and their bounce status
+	EmailMessages *email.MessageLog
+	SMS           *sms.Service
+	Push          *push.Service
+	Dispatcher    *notify.Dispatcher
+	Scheduler     *notify.Scheduler
+	Preferences   *preferences.Service
+	Devices       *devices.Registry
+	Realtime      *realtime.Hub
+	Inbox         *inbox.Service
+	Webhooks      *webhook.Service
+	Suppressions  *suppression.List
+	Limiter       *ratelimit.Limiter
+}
+
 type GRPCServer struct {
 	notification.UnimplementedNotificationServiceServer
 	server        *grpc.Server
 	healthServer  *health.Server
-	emailHandler  *emailsvc.EmailServer
+	service       notificationServer
+	authenticator *auth.Authenticator
+	tlsConfig     *tls.Config
 	shutdownGrace time.Duration
+
+	defaultTimeout time.Duration
+	methodTimeouts map[string]time.Duration
 }
 
-func NewGRPCServer(emailService *email.Service) *GRPCServer {
+func NewGRPCServer(services Services, authenticator *auth.Authenticator, tlsConfig *tls.Config) *GRPCServer {
 	return &GRPCServer{
-		emailHandler:  emailsvc.NewEmailServer(emailService),
+		service: notificationServer{
+			EmailServer:       emailsvc.NewEmailServer(services.Email, services.EmailMessages, services.Limiter),
+			SMSServer:         smssvc.NewSMSServer(services.SMS, services.Limiter),
+			PushServer:        pushsvc.NewPushServer(services.Push, services.Devices, services.Limiter),
+			NotifyServer:      notifysvc.NewNotifyServer(services.Dispatcher, services.Scheduler),
+			PreferencesServer: prefsvc.NewPreferencesServer(services.Preferences),
+			DeviceServer:      devicesvc.NewDeviceServer(services.Devices),
+			RealtimeServer:    realtimesvc.NewRealtimeServer(services.Realtime),
+			InboxServer:       inboxsvc.NewInboxServer(services.Inbox),
+			WebhookServer:     webhooksvc.NewWebhookServer(services.Webhooks),
+			SuppressionServer: suppressionsvc.NewSuppressionServer(services.Suppressions),
+		},
+		authenticator: authenticator,
+		tlsConfig:     tlsConfig,
 		shutdownGrace: 10 * time.Second,
 	}
 }
 
+// SetTimeouts configures the deadline applied to requests that arrive without one
+func (s *GRPCServer) SetTimeouts(defaultTimeout time.Duration, perMethod map[string]time.Duration) {
+	s.defaultTimeout = defaultTimeout
+	s.methodTimeouts = perMethod
+}
+
 func (s *GRPCServer) Start(port string) error {
 	lis, err := net.Listen("tcp", ":"+port)
 	if err != nil {
 		return err
 	}
 
-	s.server = grpc.NewServer(
-		grpc.ConnectionTimeout(5*time.Second),
+	opts := []grpc.ServerOption{
+		grpc.ConnectionTimeout(5 * time.Second),
 		grpc.ChainUnaryInterceptor(
+			middleware.TracingInterceptor(),
+			middleware.MetricsInterceptor(),
 			middleware.LoggingInterceptor(),
+			middleware.RecoveryInterceptor(),
+			middleware.AuthInterceptor(s.authenticator),
+			middleware.TimeoutInterceptor(s.defaultTimeout, s.methodTimeouts),
+			middleware.ValidationInterceptor(),
 		),
-	)
+		grpc.ChainStreamInterceptor(
+			middleware.StreamTracingInterceptor(),
+			middleware.StreamMetricsInterceptor(),
+			middleware.StreamLoggingInterceptor(),
+			middleware.StreamRecoveryInterceptor(),
+			middleware.StreamAuthInterceptor(s.authenticator),
+			middleware.StreamTimeoutInterceptor(s.defaultTimeout, s.methodTimeouts),
+			middleware.StreamValidationInterceptor(),
+		),
+	}
+	if s.tlsConfig != nil {
+		opts = append(opts, grpc.Creds(credentials.NewTLS(s.tlsConfig)))
+	}
+
+	s.server = grpc.NewServer(opts...)
 
 	s.healthServer = health.NewServer()
 	grpc_health_v1.RegisterHealthServer(s.server, s.healthServer)
@@ -52,10 +151,11 @@ func (s *GRPCServer) Start(port string) error {
 
 	reflection.Register(s.server)
 
-	notification.RegisterNotificationServiceServer(s.server, s.emailHandler)
+	notification.RegisterNotificationServiceServer(s.server, s.service)
 
 	logging.GetLogger().Info("gRPC server starting",
 		zap.String("port", port),
+		zap.Bool("tls", s.tlsConfig != nil),
 		zap.Duration("shutdown_grace_period", s.shutdownGrace),
 	)
 
@@ -0,0 +1,114 @@
+package smssvc
+
+import (
+	"context"
+	stderrors "errors"
+	"ride-sharing-notification/internal/pkg/errors"
+	"ride-sharing-notification/internal/pkg/logging"
+	"ride-sharing-notification/internal/pkg/ratelimit"
+	"ride-sharing-notification/internal/pkg/response"
+	"ride-sharing-notification/internal/pkg/sms"
+	"ride-sharing-notification/internal/proto/notification"
+
+	"go.uber.org/zap"
+)
+
+type Handler struct {
+	smsService *sms.Service
+	limiter    *ratelimit.Limiter
+}
+
+func NewHandler(smsService *sms.Service, limiter *ratelimit.Limiter) *Handler {
+	return &Handler{
+		smsService: smsService,
+		limiter:    limiter,
+	}
+}
+
+func (h *Handler) SendSMS(ctx context.Context, req *notification.SMSRequest) (*notification.StandardResponse, error) {
+	to, err := sms.NormalizeE164(req.To)
+	if err != nil {
+		return nil, errors.ToGRPCStatus(errors.NewValidationError("invalid request", map[string]string{
+			"to": err.Error(),
+		}))
+	}
+
+	// Raw bodies share a rate limit bucket with other untyped messages
+	smsType := req.Type
+	if smsType == "" {
+		smsType = "CUSTOM"
+	}
+
+	// Enforce rate limits
+	if appErr := h.checkRateLimit(ctx, to, smsType); appErr != nil {
+		return nil, errors.ToGRPCStatus(appErr)
+	}
+
+	data := make(map[string]interface{}, len(req.Data))
+	for k, v := range req.Data {
+		data[k] = v
+	}
+	payload := &sms.Payload{
+		To:   to,
+		Type: smsType,
+		Body: req.Body,
+		Data: data,
+	}
+
+	result, err := h.smsService.Send(ctx, payload)
+	if err != nil {
+		return nil, errors.ToGRPCStatus(sendError(err))
+	}
+
+	return response.New().
+		Success().
+		WithMessage("SMS sent successfully").
+		WithData(&notification.SMSResult{
+			Provider:  result.Provider,
+			MessageId: result.MessageID,
+			Segments:  int32(result.Segments),
+			Encoding:  string(result.Encoding),
+		}, nil)
+}
+
+// sendError maps a send failure to the error returned to the caller
+func sendError(err error) *errors.AppError {
+	// Suppressed recipients already come back as *errors.AppError
+	var appErr *errors.AppError
+	if stderrors.As(err, &appErr) {
+		return appErr
+	}
+	switch {
+	case stderrors.Is(err, sms.ErrDisabled):
+		return errors.NewUnavailableError(err.Error())
+	case stderrors.Is(err, sms.ErrUnknownType):
+		return errors.NewValidationError("invalid request", map[string]string{"type": err.Error()})
+	case stderrors.Is(err, sms.ErrMissingField):
+		return errors.NewValidationError("invalid request", map[string]string{"data": err.Error()})
+	case stderrors.Is(err, sms.ErrTooLong):
+		return errors.NewValidationError("invalid request", map[string]string{"body": err.Error()})
+	}
+
+	var providerErr *sms.ProviderError
+	if stderrors.As(err, &providerErr) && providerErr.Permanent() {
+		return errors.NewValidationError("message rejected by provider", map[string]string{
+			"to": providerErr.Message,
+		})
+	}
+	return errors.NewInternalError(err)
+}
+
+// checkRateLimit counts the send against the configured limits
+func (h *Handler) checkRateLimit(ctx context.Context, to, smsType string) *errors.AppError {
+	appErr, err := h.limiter.Check(ctx, ratelimit.Subject{
+		Recipient: to,
+		Type:      smsType,
+		Caller:    logging.CallerFromContext(ctx),
+	})
+	if err != nil {
+		logging.GetLogger().WithContext(ctx).Warn("rate limit check failed, allowing request",
+			zap.Error(err),
+		)
+	}
+	return appErr
+}
@@ -0,0 +1,25 @@
+package smssvc
+
+import (
+	"context"
+
+	"ride-sharing-notification/internal/pkg/ratelimit"
+	"ride-sharing-notification/internal/pkg/sms"
+	"ride-sharing-notification/internal/proto/notification"
+)
+
+// SMSServer implements the SMS methods of NotificationService. It is
+// combined with the other channel servers in the rpc package.
+type SMSServer struct {
+	handler *Handler
+}
+
+func NewSMSServer(smsService *sms.Service, limiter *ratelimit.Limiter) *SMSServer {
+	return &SMSServer{
+		handler: NewHandler(smsService, limiter),
+	}
+}
+
+func (s *SMSServer) SendSMS(ctx context.Context, req *notification.SMSRequest) (*notification.StandardResponse, error) {
+	return s.handler.SendSMS(ctx, req)
+}
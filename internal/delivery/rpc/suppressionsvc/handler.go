@@ -0,0 +1,140 @@
+package suppressionsvc
+
+import (
+	"context"
+	stderrors "errors"
+	"time"
+
+	"ride-sharing-notification/internal/pkg/errors"
+	"ride-sharing-notification/internal/pkg/response"
+	"ride-sharing-notification/internal/pkg/suppression"
+	"ride-sharing-notification/internal/proto/notification"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	defaultPerPage = 20
+	kindViolation  = "must be email, phone or device_token"
+)
+
+type Handler struct {
+	suppressions *suppression.List
+}
+
+func NewHandler(suppressions *suppression.List) *Handler {
+	return &Handler{
+		suppressions: suppressions,
+	}
+}
+
+func (h *Handler) AddSuppression(ctx context.Context, req *notification.AddSuppressionRequest) (*notification.StandardResponse, error) {
+	e := suppression.Entry{
+		Kind:   req.Kind,
+		Value:  req.Value,
+		Reason: req.Reason,
+		Detail: req.Detail,
+		Source: "api",
+	}
+	if req.ExpiresAt != nil {
+		e.ExpiresAt = req.ExpiresAt.AsTime()
+	}
+	added, err := h.suppressions.Add(ctx, e)
+	if err != nil {
+		return nil, errors.ToGRPCStatus(appError(err))
+	}
+
+	return response.New().
+		Success().
+		WithMessage("Suppression added successfully").
+		WithData(toProto(added), nil)
+}
+
+func (h *Handler) RemoveSuppression(ctx context.Context, req *notification.RemoveSuppressionRequest) (*notification.StandardResponse, error) {
+	if !suppression.ValidKind(req.Kind) {
+		return nil, errors.ToGRPCStatus(errors.NewValidationError("invalid request", map[string]string{
+			"kind": kindViolation,
+		}))
+	}
+	if err := h.suppressions.Remove(ctx, req.Kind, req.Value); err != nil {
+		return nil, errors.ToGRPCStatus(appError(err))
+	}
+
+	return response.New().
+		Success().
+		WithMessage("Suppression removed successfully").
+		SimpleSuccess(), nil
+}
+
+func (h *Handler) ListSuppressions(ctx context.Context, req *notification.ListSuppressionsRequest) (*notification.StandardResponse, error) {
+	violations := map[string]string{}
+	if req.Kind != "" && !suppression.ValidKind(req.Kind) {
+		violations["kind"] = kindViolation
+	}
+	if req.Reason != "" && !suppression.ValidReason(req.Reason) {
+		violations["reason"] = "must be hard_bounce, complaint, manual or unsubscribed"
+	}
+	if len(violations) > 0 {
+		return nil, errors.ToGRPCStatus(errors.NewValidationError("invalid request", violations))
+	}
+
+	page := max(int(req.Page), 1)
+	perPage := int(req.PerPage)
+	if perPage == 0 {
+		perPage = defaultPerPage
+	}
+	entries, total, err := h.suppressions.List(ctx, suppression.Query{
+		Kind:   req.Kind,
+		Value:  req.Value,
+		Reason: req.Reason,
+	}, page, perPage)
+	if err != nil {
+		return nil, errors.ToGRPCStatus(errors.NewInternalError(err))
+	}
+
+	out := &notification.SuppressionList{}
+	for i := range entries {
+		out.Suppressions = append(out.Suppressions, toProto(&entries[i]))
+	}
+	return response.New().
+		Success().
+		WithMessage("Suppressions retrieved successfully").
+		WithData(out, &notification.MetaData{
+			Page:    int32(page),
+			PerPage: int32(perPage),
+			Total:   int32(total),
+		})
+}
+
+func toProto(e *suppression.Entry) *notification.Suppression {
+	return &notification.Suppression{
+		Kind:      e.Kind,
+		Value:     e.Value,
+		Reason:    e.Reason,
+		Detail:    e.Detail,
+		Source:    e.Source,
+		CreatedAt: timestamppb.New(e.CreatedAt),
+		UpdatedAt: timestamppb.New(e.UpdatedAt),
+		ExpiresAt: timestamp(e.ExpiresAt),
+	}
+}
+
+func timestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+// appError passes validation errors through, maps unknown suppressions to
+// not found and hides everything else
+func appError(err error) *errors.AppError {
+	var appErr *errors.AppError
+	if stderrors.As(err, &appErr) {
+		return appErr
+	}
+	if stderrors.Is(err, suppression.ErrNotFound) {
+		return errors.NewNotFoundError(err.Error())
+	}
+	return errors.NewInternalError(err)
+}
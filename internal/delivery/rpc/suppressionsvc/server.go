@@ -0,0 +1,33 @@
+package suppressionsvc
+
+import (
+	"context"
+
+	"ride-sharing-notification/internal/pkg/suppression"
+	"ride-sharing-notification/internal/proto/notification"
+)
+
+// SuppressionServer implements the suppression list methods of
+// NotificationService. It is combined with the channel servers in the rpc
+// package.
+type SuppressionServer struct {
+	handler *Handler
+}
+
+func NewSuppressionServer(suppressions *suppression.List) *SuppressionServer {
+	return &SuppressionServer{
+		handler: NewHandler(suppressions),
+	}
+}
+
+func (s *SuppressionServer) AddSuppression(ctx context.Context, req *notification.AddSuppressionRequest) (*notification.StandardResponse, error) {
+	return s.handler.AddSuppression(ctx, req)
+}
+
+func (s *SuppressionServer) RemoveSuppression(ctx context.Context, req *notification.RemoveSuppressionRequest) (*notification.StandardResponse, error) {
+	return s.handler.RemoveSuppression(ctx, req)
+}
+
+func (s *SuppressionServer) ListSuppressions(ctx context.Context, req *notification.ListSuppressionsRequest) (*notification.StandardResponse, error) {
+	return s.handler.ListSuppressions(ctx, req)
+}
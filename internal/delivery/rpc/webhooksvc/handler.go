@@ -0,0 +1,167 @@
+package webhooksvc
+
+import (
+	"context"
+	stderrors "errors"
+	"time"
+
+	"ride-sharing-notification/internal/pkg/errors"
+	"ride-sharing-notification/internal/pkg/response"
+	"ride-sharing-notification/internal/pkg/webhook"
+	"ride-sharing-notification/internal/proto/notification"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const defaultPerPage = 20
+
+type Handler struct {
+	webhooks *webhook.Service
+}
+
+func NewHandler(webhooks *webhook.Service) *Handler {
+	return &Handler{
+		webhooks: webhooks,
+	}
+}
+
+func (h *Handler) CreateWebhook(ctx context.Context, req *notification.CreateWebhookRequest) (*notification.StandardResponse, error) {
+	sub, err := h.webhooks.Create(ctx, webhook.Subscription{
+		AccountID:  req.AccountId,
+		URL:        req.Url,
+		EventTypes: req.EventTypes,
+		Secret:     req.Secret,
+	})
+	if err != nil {
+		return nil, errors.ToGRPCStatus(appError(err))
+	}
+
+	return response.New().
+		Success().
+		WithMessage("Webhook created successfully").
+		WithData(toProto(sub, true), nil)
+}
+
+func (h *Handler) UpdateWebhook(ctx context.Context, req *notification.UpdateWebhookRequest) (*notification.StandardResponse, error) {
+	sub, err := h.webhooks.Update(ctx, req.Id, webhook.Change{
+		URL:          req.Url,
+		EventTypes:   req.EventTypes,
+		Enabled:      req.Enabled,
+		RotateSecret: req.RotateSecret,
+	})
+	if err != nil {
+		return nil, errors.ToGRPCStatus(appError(err))
+	}
+
+	return response.New().
+		Success().
+		WithMessage("Webhook updated successfully").
+		WithData(toProto(sub, req.RotateSecret), nil)
+}
+
+func (h *Handler) DeleteWebhook(ctx context.Context, req *notification.DeleteWebhookRequest) (*notification.StandardResponse, error) {
+	if err := h.webhooks.Delete(ctx, req.Id); err != nil {
+		return nil, errors.ToGRPCStatus(appError(err))
+	}
+
+	return response.New().
+		Success().
+		WithMessage("Webhook deleted successfully").
+		SimpleSuccess(), nil
+}
+
+func (h *Handler) ListWebhooks(ctx context.Context, req *notification.ListWebhooksRequest) (*notification.StandardResponse, error) {
+	subs, err := h.webhooks.List(ctx, req.AccountId)
+	if err != nil {
+		return nil, errors.ToGRPCStatus(errors.NewInternalError(err))
+	}
+
+	out := &notification.WebhookList{}
+	for i := range subs {
+		out.Subscriptions = append(out.Subscriptions, toProto(&subs[i], false))
+	}
+	return response.New().
+		Success().
+		WithMessage("Webhooks retrieved successfully").
+		WithData(out, nil)
+}
+
+func (h *Handler) ListWebhookDeliveries(ctx context.Context, req *notification.ListWebhookDeliveriesRequest) (*notification.StandardResponse, error) {
+	page := max(int(req.Page), 1)
+	perPage := int(req.PerPage)
+	if perPage == 0 {
+		perPage = defaultPerPage
+	}
+	deliveries, total, err := h.webhooks.Deliveries(ctx, req.SubscriptionId, req.Status, page, perPage)
+	if err != nil {
+		return nil, errors.ToGRPCStatus(appError(err))
+	}
+
+	out := &notification.WebhookDeliveryList{}
+	for i := range deliveries {
+		out.Deliveries = append(out.Deliveries, deliveryToProto(&deliveries[i]))
+	}
+	return response.New().
+		Success().
+		WithMessage("Webhook deliveries retrieved successfully").
+		WithData(out, &notification.MetaData{
+			Page:    int32(page),
+			PerPage: int32(perPage),
+			Total:   int32(total),
+		})
+}
+
+// toProto leaves out the secret unless withSecret is set
+func toProto(sub *webhook.Subscription, withSecret bool) *notification.WebhookSubscription {
+	out := &notification.WebhookSubscription{
+		Id:                  sub.ID,
+		AccountId:           sub.AccountID,
+		Url:                 sub.URL,
+		EventTypes:          sub.EventTypes,
+		Enabled:             sub.Enabled,
+		ConsecutiveFailures: int32(sub.ConsecutiveFailures),
+		DisabledReason:      sub.DisabledReason,
+		CreatedAt:           timestamppb.New(sub.CreatedAt),
+		UpdatedAt:           timestamppb.New(sub.UpdatedAt),
+	}
+	if withSecret {
+		out.Secret = sub.Secret
+	}
+	return out
+}
+
+func deliveryToProto(d *webhook.Delivery) *notification.WebhookDelivery {
+	return &notification.WebhookDelivery{
+		Id:            d.ID,
+		EventId:       d.EventID,
+		EventType:     d.EventType,
+		Status:        d.Status,
+		Attempts:      int32(d.Attempts),
+		ResponseCode:  int32(d.ResponseCode),
+		Error:         d.Error,
+		CreatedAt:     timestamppb.New(d.CreatedAt),
+		LastAttemptAt: timestamp(d.LastAttemptAt),
+		NextAttemptAt: timestamp(d.NextAttemptAt),
+		DeliveredAt:   timestamp(d.DeliveredAt),
+	}
+}
+
+func timestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+// appError passes validation errors through, maps unknown subscriptions to
+// not found and hides everything else
+func appError(err error) *errors.AppError {
+	var appErr *errors.AppError
+	if stderrors.As(err, &appErr) {
+		return appErr
+	}
+	if stderrors.Is(err, webhook.ErrNotFound) {
+		return errors.NewNotFoundError(err.Error())
+	}
+	return errors.NewInternalError(err)
+}
@@ -0,0 +1,41 @@
+package webhooksvc
+
+import (
+	"context"
+
+	"ride-sharing-notification/internal/pkg/webhook"
+	"ride-sharing-notification/internal/proto/notification"
+)
+
+// WebhookServer implements the webhook subscription methods of
+// NotificationService. It is combined with the channel servers in the rpc
+// package.
+type WebhookServer struct {
+	handler *Handler
+}
+
+func NewWebhookServer(webhooks *webhook.Service) *WebhookServer {
+	return &WebhookServer{
+		handler: NewHandler(webhooks),
+	}
+}
+
+func (s *WebhookServer) CreateWebhook(ctx context.Context, req *notification.CreateWebhookRequest) (*notification.StandardResponse, error) {
+	return s.handler.CreateWebhook(ctx, req)
+}
+
+func (s *WebhookServer) UpdateWebhook(ctx context.Context, req *notification.UpdateWebhookRequest) (*notification.StandardResponse, error) {
+	return s.handler.UpdateWebhook(ctx, req)
+}
+
+func (s *WebhookServer) DeleteWebhook(ctx context.Context, req *notification.DeleteWebhookRequest) (*notification.StandardResponse, error) {
+	return s.handler.DeleteWebhook(ctx, req)
+}
+
+func (s *WebhookServer) ListWebhooks(ctx context.Context, req *notification.ListWebhooksRequest) (*notification.StandardResponse, error) {
+	return s.handler.ListWebhooks(ctx, req)
+}
+
+func (s *WebhookServer) ListWebhookDeliveries(ctx context.Context, req *notification.ListWebhookDeliveriesRequest) (*notification.StandardResponse, error) {
+	return s.handler.ListWebhookDeliveries(ctx, req)
+}
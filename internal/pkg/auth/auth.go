@@ -0,0 +1,309 @@
+package auth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"fmt"
+	"strings"
+	"time"
+
+	"ride-sharing-notification/config"
+	"ride-sharing-notification/internal/pkg/errors"
+
+	"github.com/golang-jwt/jwt/v5"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	authorizationHeader = "authorization"
+	bearerPrefix        = "bearer "
+
+	SourceJWT = "jwt"
+)
+
+// Identity is the authenticated service calling the API
+type Identity struct {
+	Service string
+	Subject string
+	Source  string
+	// Delegate services may act for any user
+	Delegate bool
+}
+
+type identityKey struct{}
+
+// WithIdentity returns ctx carrying the caller identity
+func WithIdentity(ctx context.Context, id Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, id)
+}
+
+// IdentityFromContext returns the caller identity set by the auth interceptor
+func IdentityFromContext(ctx context.Context) (Identity, bool) {
+	id, ok := ctx.Value(identityKey{}).(Identity)
+	return id, ok
+}
+
+// Claims are the JWT claims expected from calling services. The service name
+// comes from the "service" claim, falling back to "sub".
+type Claims struct {
+	Service string `json:"service,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// Verifier validates HS256 tokens against shared secrets and RS256/ES256
+// tokens against keys from a JWKS file
+type Verifier struct {
+	secrets    [][]byte
+	publicKeys []publicKey
+	parser     *jwt.Parser
+}
+
+func NewVerifier(secrets []string, jwksFile, issuer, audience string) (*Verifier, error) {
+	v := &Verifier{}
+	methods := []string{}
+
+	for _, secret := range secrets {
+		if secret != "" {
+			v.secrets = append(v.secrets, []byte(secret))
+		}
+	}
+	if len(v.secrets) > 0 {
+		methods = append(methods, jwt.SigningMethodHS256.Alg())
+	}
+
+	if jwksFile != "" {
+		keys, err := loadJWKS(jwksFile)
+		if err != nil {
+			return nil, err
+		}
+		v.publicKeys = keys
+		methods = append(methods, jwt.SigningMethodRS256.Alg(), jwt.SigningMethodES256.Alg())
+	}
+
+	if len(methods) == 0 {
+		return nil, fmt.Errorf("no JWT secrets or JWKS file configured")
+	}
+
+	opts := []jwt.ParserOption{
+		jwt.WithValidMethods(methods),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(30 * time.Second),
+	}
+	if issuer != "" {
+		opts = append(opts, jwt.WithIssuer(issuer))
+	}
+	if audience != "" {
+		opts = append(opts, jwt.WithAudience(audience))
+	}
+	v.parser = jwt.NewParser(opts...)
+
+	return v, nil
+}
+
+// Verify parses and validates a raw token and returns the caller identity
+func (v *Verifier) Verify(raw string) (Identity, error) {
+	claims := &Claims{}
+	if _, err := v.parser.ParseWithClaims(raw, claims, v.keyFunc); err != nil {
+		return Identity{}, err
+	}
+
+	service := claims.Service
+	if service == "" {
+		service = claims.Subject
+	}
+	if service == "" {
+		return Identity{}, fmt.Errorf("token does not identify a service")
+	}
+
+	return Identity{
+		Service: service,
+		Subject: claims.Subject,
+		Source:  SourceJWT,
+	}, nil
+}
+
+func (v *Verifier) keyFunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+
+	var candidates []jwt.VerificationKey
+	switch token.Method.(type) {
+	case *jwt.SigningMethodHMAC:
+		for _, secret := range v.secrets {
+			candidates = append(candidates, secret)
+		}
+	case *jwt.SigningMethodRSA:
+		for _, k := range v.publicKeys {
+			if rsaKey, ok := k.key.(*rsa.PublicKey); ok && (kid == "" || k.kid == kid) {
+				candidates = append(candidates, rsaKey)
+			}
+		}
+	case *jwt.SigningMethodECDSA:
+		for _, k := range v.publicKeys {
+			if ecKey, ok := k.key.(*ecdsa.PublicKey); ok && (kid == "" || k.kid == kid) {
+				candidates = append(candidates, ecKey)
+			}
+		}
+	}
+
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("no verification key for alg %s kid %q", token.Method.Alg(), kid)
+	}
+	return jwt.VerificationKeySet{Keys: candidates}, nil
+}
+
+// Policy restricts which services may call which RPCs. Methods without an
+// entry are denied to every caller.
+type Policy struct {
+	allow     map[string]map[string]struct{}
+	delegates map[string]struct{}
+}
+
+// NewPolicy builds a policy from method → services. Methods may be given as
+// full gRPC names ("/notification.NotificationService/SendPush") or bare RPC
+// names ("SendPush"); a service of "*" allows every authenticated caller.
+// delegates are the services that may act for any user.
+func NewPolicy(allowlist map[string][]string, delegates []string) *Policy {
+	p := &Policy{
+		allow:     make(map[string]map[string]struct{}, len(allowlist)),
+		delegates: make(map[string]struct{}, len(delegates)),
+	}
+	for method, services := range allowlist {
+		set := make(map[string]struct{}, len(services))
+		for _, svc := range services {
+			set[svc] = struct{}{}
+		}
+		p.allow[method] = set
+	}
+	for _, svc := range delegates {
+		p.delegates[svc] = struct{}{}
+	}
+	return p
+}
+
+// Delegate reports whether service may act for any user
+func (p *Policy) Delegate(service string) bool {
+	_, ok := p.delegates[service]
+	return ok
+}
+
+// Allowed reports whether service may call fullMethod
+func (p *Policy) Allowed(fullMethod, service string) bool {
+	set, ok := p.allow[fullMethod]
+	if !ok {
+		set, ok = p.allow[fullMethod[strings.LastIndex(fullMethod, "/")+1:]]
+	}
+	if !ok {
+		return false
+	}
+	if _, ok := set["*"]; ok {
+		return true
+	}
+	_, ok = set[service]
+	return ok
+}
+
+// Authenticator resolves and authorizes the caller of each RPC
+type Authenticator struct {
+	verifier *Verifier
+	policy   *Policy
+}
+
+func NewAuthenticator(verifier *Verifier, policy *Policy) *Authenticator {
+	return &Authenticator{
+		verifier: verifier,
+		policy:   policy,
+	}
+}
+
+// NewFromConfig builds the authenticator from the service configuration.
+// It returns nil when authentication is disabled.
+func NewFromConfig(cfg *config.Config) (*Authenticator, error) {
+	if !cfg.Auth.Enabled {
+		return nil, nil
+	}
+
+	// With mutual TLS and no token keys, callers are identified by certificate only
+	mtlsOnly := cfg.JWT.AccessSecret == "" && cfg.Auth.JWKSFile == "" && cfg.GRPC.TLS.ClientCAFile != ""
+
+	var verifier *Verifier
+	if !mtlsOnly {
+		var err error
+		// Refresh tokens only renew access tokens and are never credentials
+		verifier, err = NewVerifier(
+			[]string{cfg.JWT.AccessSecret},
+			cfg.Auth.JWKSFile,
+			cfg.Auth.Issuer,
+			cfg.Auth.Audience,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return NewAuthenticator(verifier, NewPolicy(cfg.Auth.Allowlist, cfg.Auth.Delegates)), nil
+}
+
+// Authenticate identifies the caller from the bearer token in the incoming
+// metadata, or failing that its verified client certificate, and checks it
+// against the method allowlist
+func (a *Authenticator) Authenticate(ctx context.Context, fullMethod string) (context.Context, *errors.AppError) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(authorizationHeader)
+	if len(values) == 0 {
+		// A verified client certificate is enough to identify the caller
+		if id, ok := PeerIdentity(ctx); ok {
+			return a.authorize(ctx, fullMethod, id)
+		}
+		return ctx, errors.NewUnauthorizedError("missing bearer token or client certificate")
+	}
+
+	if a.verifier == nil {
+		return ctx, errors.NewUnauthorizedError("bearer tokens are not accepted, present a client certificate")
+	}
+
+	raw := values[0]
+	if len(raw) < len(bearerPrefix) || !strings.EqualFold(raw[:len(bearerPrefix)], bearerPrefix) {
+		return ctx, errors.NewUnauthorizedError("authorization header must use the Bearer scheme")
+	}
+
+	id, err := a.verifier.Verify(strings.TrimSpace(raw[len(bearerPrefix):]))
+	if err != nil {
+		appErr := errors.NewUnauthorizedError("invalid token")
+		appErr.Err = err
+		return ctx, appErr
+	}
+
+	return a.authorize(ctx, fullMethod, id)
+}
+
+func (a *Authenticator) authorize(ctx context.Context, fullMethod string, id Identity) (context.Context, *errors.AppError) {
+	if !a.policy.Allowed(fullMethod, id.Service) {
+		return ctx, errors.NewForbiddenError(fmt.Sprintf("service %q may not call %s", id.Service, fullMethod))
+	}
+	id.Delegate = a.policy.Delegate(id.Service)
+	return WithIdentity(ctx, id), nil
+}
+
+// ResolveUser returns the user a per-user RPC acts for. Callers act for
+// their own subject, and naming another user in userID is forbidden unless
+// the caller is a delegate service. Without authentication userID is taken
+// as given.
+func ResolveUser(ctx context.Context, userID string) (string, *errors.AppError) {
+	id, ok := IdentityFromContext(ctx)
+	switch {
+	case ok && !id.Delegate:
+		if userID != "" && userID != id.Subject {
+			return "", errors.NewForbiddenError("user_id must match the authenticated subject")
+		}
+		userID = id.Subject
+	case ok && userID == "":
+		userID = id.Subject
+	}
+	if userID == "" {
+		return "", errors.NewValidationError("invalid request", map[string]string{
+			"user_id": "required unless the caller is authenticated as the user",
+		})
+	}
+	return userID, nil
+}
@@ -0,0 +1,44 @@
+package auth
+
+import (
+	"context"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+const SourceMTLS = "mtls"
+
+// PeerIdentity derives the caller identity from a verified client
+// certificate. The first DNS SAN is preferred, then the first URI SAN
+// (e.g. a SPIFFE ID), then the subject common name.
+func PeerIdentity(ctx context.Context) (Identity, bool) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return Identity{}, false
+	}
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.VerifiedChains) == 0 {
+		return Identity{}, false
+	}
+
+	leaf := tlsInfo.State.VerifiedChains[0][0]
+	var name string
+	switch {
+	case len(leaf.DNSNames) > 0:
+		name = leaf.DNSNames[0]
+	case len(leaf.URIs) > 0:
+		name = leaf.URIs[0].String()
+	default:
+		name = leaf.Subject.CommonName
+	}
+	if name == "" {
+		return Identity{}, false
+	}
+
+	return Identity{
+		Service: name,
+		Subject: leaf.Subject.String(),
+		Source:  SourceMTLS,
+	}, true
+}
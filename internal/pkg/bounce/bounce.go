@@ -0,0 +1,184 @@
+package bounce
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"ride-sharing-notification/config"
+	"ride-sharing-notification/internal/pkg/email"
+	"ride-sharing-notification/internal/pkg/logging"
+	"ride-sharing-notification/internal/pkg/metrics"
+	"ride-sharing-notification/internal/pkg/storage"
+	"ride-sharing-notification/internal/pkg/suppression"
+
+	"go.uber.org/zap"
+)
+
+// Report types
+const (
+	TypeBounce    = "bounce"
+	TypeComplaint = "complaint"
+)
+
+// Sources reports come from
+const (
+	SourceMbox    = "mbox"
+	SourceSES     = "ses"
+	SourceWebhook = "webhook"
+)
+
+// Report says that an email bounced or drew a complaint
+type Report struct {
+	Type      string `json:"type"`
+	Recipient string `json:"recipient"`
+	// MessageID is the Message-ID of the email reported on, if known
+	MessageID string `json:"message_id"`
+	// Permanent marks hard bounces; soft bounces may succeed later
+	Permanent bool `json:"permanent"`
+	// Status is the enhanced status code of a bounce, e.g. 5.1.1
+	Status string `json:"status"`
+	// Diagnostic is the bounce diagnostic or the complaint feedback type
+	Diagnostic string `json:"diagnostic"`
+	Source     string `json:"source"`
+}
+
+// Processor applies bounce and complaint reports: the email reported on is
+// marked bounced or complained, and after hard bounces and complaints the
+// address is suppressed.
+type Processor struct {
+	messages     *email.MessageLog
+	suppressions *suppression.List
+	kv           storage.KV
+	// mbox is the mailbox file polled for reports; empty turns polling off
+	mbox string
+}
+
+func NewProcessor(messages *email.MessageLog, suppressions *suppression.List, kv storage.KV, mbox string) *Processor {
+	return &Processor{
+		messages:     messages,
+		suppressions: suppressions,
+		kv:           kv,
+		mbox:         mbox,
+	}
+}
+
+func NewFromConfig(messages *email.MessageLog, suppressions *suppression.List, kv storage.KV, cfg *config.Config) *Processor {
+	return NewProcessor(messages, suppressions, kv, cfg.Bounce.Mbox)
+}
+
+// Process applies one report. Reports that match no email still on record
+// are dropped, so a forged report cannot suppress an arbitrary address.
+func (p *Processor) Process(ctx context.Context, r Report) error {
+	status, reason := email.StatusBounced, r.Diagnostic
+	switch {
+	case r.Type == TypeComplaint:
+		status = email.StatusComplained
+	case r.Type != TypeBounce:
+		return fmt.Errorf("unknown report type %q", r.Type)
+	case !r.Permanent:
+		status = email.StatusSoftBounced
+	}
+	if r.Status != "" && reason != "" {
+		reason = r.Status + " " + reason
+	} else if r.Status != "" {
+		reason = r.Status
+	}
+
+	logger := logging.GetLogger().WithContext(ctx).With(
+		zap.String("report_type", r.Type),
+		zap.String("source", r.Source),
+		zap.String("recipient", logging.Mask("email", r.Recipient)),
+		zap.String("message_id", r.MessageID),
+	)
+
+	// Without a Message-ID the report is about the last email to the
+	// recipient
+	var m *email.Message
+	var err error
+	switch {
+	case r.MessageID != "":
+		m, err = p.messages.Get(ctx, r.MessageID)
+	case r.Recipient != "":
+		m, err = p.messages.LastTo(ctx, r.Recipient)
+	default:
+		logger.Warn("dropping report without recipient")
+		return nil
+	}
+	if err == nil && r.Recipient != "" && !strings.EqualFold(strings.TrimSpace(r.Recipient), m.To) {
+		logger.Warn("dropping report for another recipient of the email")
+		return nil
+	}
+	if err == nil {
+		m, err = p.messages.SetStatus(ctx, m.ID, status, reason)
+	}
+	switch {
+	case errors.Is(err, email.ErrMessageNotFound):
+		logger.Info("dropping report for unknown email")
+		return nil
+	case err != nil:
+		return err
+	}
+	r.Recipient = m.To
+
+	metrics.EmailBounceReportsTotal.WithLabelValues(status).Inc()
+	if status == email.StatusSoftBounced {
+		logger.Info("email soft bounced")
+		return nil
+	}
+
+	suppressionReason := suppression.ReasonHardBounce
+	if status == email.StatusComplained {
+		suppressionReason = suppression.ReasonComplaint
+	}
+	if _, err := p.suppressions.Add(ctx, suppression.Entry{
+		Kind:   suppression.KindEmail,
+		Value:  r.Recipient,
+		Reason: suppressionReason,
+		Detail: reason,
+		Source: r.Source,
+	}); err != nil {
+		return err
+	}
+	logger.Info("suppressed email address", zap.String("reason", suppressionReason))
+	return nil
+}
+
+// ProcessAll applies every report and returns the first error
+func (p *Processor) ProcessAll(ctx context.Context, reports []Report) error {
+	var first error
+	for _, r := range reports {
+		if err := p.Process(ctx, r); err != nil && first == nil {
+			first = err
+		}
+	}
+	return first
+}
+
+// Run polls the mailbox for new reports, if one is configured, and forgets
+// emails past their retention and expired suppressions every interval until
+// ctx is done
+func (p *Processor) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if p.mbox != "" {
+				if err := p.pollMbox(ctx); err != nil {
+					logging.GetLogger().Error("failed to read bounce mailbox", zap.String("path", p.mbox), zap.Error(err))
+				}
+			}
+			if _, err := p.messages.Expire(ctx); err != nil {
+				logging.GetLogger().Error("failed to expire sent emails", zap.Error(err))
+			}
+			if _, err := p.suppressions.Expire(ctx); err != nil {
+				logging.GetLogger().Error("failed to expire suppressions", zap.Error(err))
+			}
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package bounce
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"ride-sharing-notification/internal/pkg/email"
+	"ride-sharing-notification/internal/pkg/storage"
+	"ride-sharing-notification/internal/pkg/suppression"
+)
+
+func TestProcess(t *testing.T) {
+	tests := []struct {
+		name           string
+		report         Report
+		wantSuppressed string
+		wantStatus     string
+	}{
+		{
+			name:           "hard bounce by Message-ID",
+			report:         Report{Type: TypeBounce, MessageID: "<abc@ride.example.com>", Recipient: "Rider@Example.com", Permanent: true},
+			wantSuppressed: "rider@example.com",
+			wantStatus:     email.StatusBounced,
+		},
+		{
+			name:           "complaint by recipient",
+			report:         Report{Type: TypeComplaint, Recipient: "rider@example.com"},
+			wantSuppressed: "rider@example.com",
+			wantStatus:     email.StatusComplained,
+		},
+		{
+			name:           "Message-ID without recipient",
+			report:         Report{Type: TypeBounce, MessageID: "abc@ride.example.com", Permanent: true},
+			wantSuppressed: "rider@example.com",
+			wantStatus:     email.StatusBounced,
+		},
+		{
+			name:       "soft bounce",
+			report:     Report{Type: TypeBounce, MessageID: "<abc@ride.example.com>", Recipient: "rider@example.com"},
+			wantStatus: email.StatusSoftBounced,
+		},
+		{
+			name:       "unknown Message-ID",
+			report:     Report{Type: TypeBounce, MessageID: "<forged@example.com>", Recipient: "rider@example.com", Permanent: true},
+			wantStatus: email.StatusSent,
+		},
+		{
+			name:       "recipient never emailed",
+			report:     Report{Type: TypeComplaint, Recipient: "someone@example.com"},
+			wantStatus: email.StatusSent,
+		},
+		{
+			name:       "recipient of another email",
+			report:     Report{Type: TypeBounce, MessageID: "<abc@ride.example.com>", Recipient: "someone@example.com", Permanent: true},
+			wantStatus: email.StatusSent,
+		},
+		{
+			name:       "no recipient or Message-ID",
+			report:     Report{Type: TypeBounce, Permanent: true},
+			wantStatus: email.StatusSent,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			kv := storage.NewMemory()
+			messages := email.NewMessageLog(kv, time.Hour)
+			suppressions := suppression.NewList(kv)
+			if err := messages.Record(ctx, email.Message{ID: "<abc@ride.example.com>", To: "rider@example.com"}); err != nil {
+				t.Fatal(err)
+			}
+
+			p := NewProcessor(messages, suppressions, kv, "")
+			if err := p.Process(ctx, tt.report); err != nil {
+				t.Fatalf("Process() error = %v", err)
+			}
+
+			for _, address := range []string{"rider@example.com", "someone@example.com"} {
+				_, err := suppressions.Get(ctx, suppression.KindEmail, address)
+				if suppressed := err == nil; suppressed != (address == tt.wantSuppressed) {
+					t.Errorf("%s suppressed = %v, want %v", address, suppressed, !suppressed)
+				}
+			}
+			m, err := messages.Get(ctx, "<abc@ride.example.com>")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if m.Status != tt.wantStatus {
+				t.Errorf("email status = %s, want %s", m.Status, tt.wantStatus)
+			}
+		})
+	}
+}
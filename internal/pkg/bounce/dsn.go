@@ -0,0 +1,176 @@
+package bounce
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"net/textproto"
+	"strings"
+)
+
+// ParseMessage extracts the reports from a bounce or complaint email: a
+// delivery status notification (RFC 3464) or an abuse feedback report
+// (RFC 5965). Other messages, such as auto-replies, yield no reports.
+func ParseMessage(r io.Reader) ([]Report, error) {
+	msg, err := mail.ReadMessage(r)
+	if err != nil {
+		return nil, fmt.Errorf("read message: %w", err)
+	}
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/report" {
+		return nil, nil
+	}
+
+	var status, feedback []byte
+	var original mail.Header
+	parts := multipart.NewReader(msg.Body, params["boundary"])
+	for {
+		part, err := parts.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read report part: %w", err)
+		}
+		body, err := readPart(part)
+		if err != nil {
+			return nil, err
+		}
+		partType, _, _ := mime.ParseMediaType(part.Header.Get("Content-Type"))
+		switch partType {
+		case "message/delivery-status", "message/global-delivery-status":
+			status = body
+		case "message/feedback-report":
+			feedback = body
+		case "message/rfc822", "message/global", "text/rfc822-headers", "message/global-headers":
+			original = parseHeaders(body)
+		}
+	}
+
+	messageID := ""
+	if original != nil {
+		messageID = original.Get("Message-Id")
+	}
+	switch {
+	case feedback != nil:
+		return feedbackReports(feedback, original, messageID), nil
+	case status != nil:
+		return statusReports(status, messageID), nil
+	}
+	return nil, nil
+}
+
+// statusReports returns a bounce for every recipient whose delivery failed;
+// delayed deliveries are still being retried and are left out
+func statusReports(status []byte, messageID string) []Report {
+	var reports []Report
+	for _, fields := range fieldGroups(status) {
+		// The group describing the message itself has no action
+		if !strings.EqualFold(fields.Get("Action"), "failed") {
+			continue
+		}
+		recipient := addressField(fields.Get("Final-Recipient"))
+		if recipient == "" {
+			recipient = addressField(fields.Get("Original-Recipient"))
+		}
+		code := strings.TrimSpace(fields.Get("Status"))
+		reports = append(reports, Report{
+			Type:       TypeBounce,
+			Recipient:  recipient,
+			MessageID:  messageID,
+			Permanent:  !strings.HasPrefix(code, "4"),
+			Status:     code,
+			Diagnostic: typedField(fields.Get("Diagnostic-Code")),
+		})
+	}
+	return reports
+}
+
+func feedbackReports(feedback []byte, original mail.Header, messageID string) []Report {
+	groups := fieldGroups(feedback)
+	if len(groups) == 0 {
+		return nil
+	}
+	fields := groups[0]
+	recipient := strings.Trim(strings.TrimSpace(fields.Get("Original-Rcpt-To")), "<>")
+	if recipient == "" && original != nil {
+		// Some providers redact the recipient from the report but not from
+		// the original headers
+		if addr, err := mail.ParseAddress(original.Get("To")); err == nil {
+			recipient = addr.Address
+		}
+	}
+	feedbackType := strings.ToLower(strings.TrimSpace(fields.Get("Feedback-Type")))
+	if feedbackType == "" {
+		feedbackType = "abuse"
+	}
+	return []Report{{
+		Type:       TypeComplaint,
+		Recipient:  recipient,
+		MessageID:  messageID,
+		Diagnostic: feedbackType,
+	}}
+}
+
+// readPart returns the decoded body of a report part. Quoted-printable is
+// decoded by the multipart reader itself.
+func readPart(part *multipart.Part) ([]byte, error) {
+	var r io.Reader = part
+	if strings.EqualFold(part.Header.Get("Content-Transfer-Encoding"), "base64") {
+		r = base64.NewDecoder(base64.StdEncoding, part)
+	}
+	body, err := io.ReadAll(io.LimitReader(r, 1<<20))
+	if err != nil {
+		return nil, fmt.Errorf("read report part: %w", err)
+	}
+	return body, nil
+}
+
+// parseHeaders reads the header of an attached message, which may come
+// without a body
+func parseHeaders(body []byte) mail.Header {
+	header, err := textproto.NewReader(bufio.NewReader(bytes.NewReader(append(body, "\r\n\r\n"...)))).ReadMIMEHeader()
+	if err != nil && len(header) == 0 {
+		return nil
+	}
+	return mail.Header(header)
+}
+
+// fieldGroups parses the blank-line separated header blocks of a delivery
+// status or feedback report
+func fieldGroups(body []byte) []textproto.MIMEHeader {
+	normalized := strings.ReplaceAll(string(body), "\r\n", "\n")
+	var groups []textproto.MIMEHeader
+	for _, block := range strings.Split(normalized, "\n\n") {
+		if strings.TrimSpace(block) == "" {
+			continue
+		}
+		r := textproto.NewReader(bufio.NewReader(strings.NewReader(strings.TrimLeft(block, "\n") + "\n\n")))
+		fields, err := r.ReadMIMEHeader()
+		if err != nil && len(fields) == 0 {
+			continue
+		}
+		groups = append(groups, fields)
+	}
+	return groups
+}
+
+// typedField strips the type of a typed field such as
+// "smtp; 550 5.1.1 unknown user"
+func typedField(value string) string {
+	if _, rest, ok := strings.Cut(value, ";"); ok {
+		value = rest
+	}
+	return strings.TrimSpace(value)
+}
+
+// addressField returns the address of a field such as
+// "rfc822; rider@example.com"
+func addressField(value string) string {
+	return strings.Trim(typedField(value), "<>")
+}
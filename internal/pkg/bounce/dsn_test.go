@@ -0,0 +1,188 @@
+package bounce
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+// report builds a multipart/report message from its parts, each given as
+// its headers and body
+func report(reportType string, parts ...[2]string) string {
+	var b strings.Builder
+	b.WriteString("From: MAILER-DAEMON@mx.example.com\r\n")
+	b.WriteString("To: bounces@ride.example.com\r\n")
+	b.WriteString("Subject: Undelivered Mail Returned to Sender\r\n")
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: multipart/report; report-type=" + reportType + "; boundary=\"BOUNDARY\"\r\n\r\n")
+	for _, part := range parts {
+		b.WriteString("--BOUNDARY\r\n")
+		b.WriteString(part[0] + "\r\n\r\n")
+		b.WriteString(part[1] + "\r\n")
+	}
+	b.WriteString("--BOUNDARY--\r\n")
+	return b.String()
+}
+
+const originalHeaders = "From: no-reply@ride.example.com\r\n" +
+	"To: Rider <rider@example.com>\r\n" +
+	"Message-ID: <abc123@ride.example.com>\r\n" +
+	"Subject: Your receipt"
+
+var humanPart = [2]string{"Content-Type: text/plain", "Your message could not be delivered."}
+
+func TestParseMessage(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		want    []Report
+	}{
+		{
+			name: "hard bounce",
+			message: report("delivery-status",
+				humanPart,
+				[2]string{"Content-Type: message/delivery-status",
+					"Reporting-MTA: dns; mx.example.com\r\n\r\n" +
+						"Final-Recipient: rfc822; rider@example.com\r\n" +
+						"Action: failed\r\n" +
+						"Status: 5.1.1\r\n" +
+						"Diagnostic-Code: smtp; 550 5.1.1 user unknown"},
+				[2]string{"Content-Type: text/rfc822-headers", originalHeaders},
+			),
+			want: []Report{{
+				Type:       TypeBounce,
+				Recipient:  "rider@example.com",
+				MessageID:  "<abc123@ride.example.com>",
+				Permanent:  true,
+				Status:     "5.1.1",
+				Diagnostic: "550 5.1.1 user unknown",
+			}},
+		},
+		{
+			name: "soft bounce without original message",
+			message: report("delivery-status",
+				humanPart,
+				[2]string{"Content-Type: message/delivery-status",
+					"Reporting-MTA: dns; mx.example.com\r\n\r\n" +
+						"Original-Recipient: rfc822;<driver@example.com>\r\n" +
+						"Action: failed\r\n" +
+						"Status: 4.2.2\r\n" +
+						"Diagnostic-Code: smtp; 452 4.2.2 mailbox full"},
+			),
+			want: []Report{{
+				Type:       TypeBounce,
+				Recipient:  "driver@example.com",
+				Status:     "4.2.2",
+				Diagnostic: "452 4.2.2 mailbox full",
+			}},
+		},
+		{
+			name: "delayed delivery",
+			message: report("delivery-status",
+				humanPart,
+				[2]string{"Content-Type: message/delivery-status",
+					"Reporting-MTA: dns; mx.example.com\r\n\r\n" +
+						"Final-Recipient: rfc822; rider@example.com\r\n" +
+						"Action: delayed\r\n" +
+						"Status: 4.4.1"},
+			),
+			want: nil,
+		},
+		{
+			name: "several recipients",
+			message: report("delivery-status",
+				humanPart,
+				[2]string{"Content-Type: message/delivery-status",
+					"Reporting-MTA: dns; mx.example.com\r\n\r\n" +
+						"Final-Recipient: rfc822; a@example.com\r\n" +
+						"Action: failed\r\n" +
+						"Status: 5.1.1\r\n\r\n" +
+						"Final-Recipient: rfc822; b@example.com\r\n" +
+						"Action: delivered\r\n" +
+						"Status: 2.0.0\r\n\r\n" +
+						"Final-Recipient: rfc822; c@example.com\r\n" +
+						"Action: failed\r\n" +
+						"Status: 5.2.1"},
+			),
+			want: []Report{
+				{Type: TypeBounce, Recipient: "a@example.com", Permanent: true, Status: "5.1.1"},
+				{Type: TypeBounce, Recipient: "c@example.com", Permanent: true, Status: "5.2.1"},
+			},
+		},
+		{
+			name: "base64 encoded status",
+			message: report("delivery-status",
+				humanPart,
+				[2]string{"Content-Type: message/delivery-status\r\nContent-Transfer-Encoding: base64",
+					// Final-Recipient: rfc822; rider@example.com / Action: failed / Status: 5.1.1
+					"RmluYWwtUmVjaXBpZW50OiByZmM4MjI7IHJpZGVyQGV4YW1wbGUuY29tDQpBY3Rpb246IGZhaWxlZA0KU3RhdHVzOiA1LjEuMQ0K"},
+			),
+			want: []Report{{
+				Type:      TypeBounce,
+				Recipient: "rider@example.com",
+				Permanent: true,
+				Status:    "5.1.1",
+			}},
+		},
+		{
+			name: "complaint",
+			message: report("feedback-report",
+				humanPart,
+				[2]string{"Content-Type: message/feedback-report",
+					"Feedback-Type: abuse\r\n" +
+						"User-Agent: ExampleFBL/1.0\r\n" +
+						"Version: 1\r\n" +
+						"Original-Rcpt-To: <rider@example.com>"},
+				[2]string{"Content-Type: message/rfc822", originalHeaders + "\r\n\r\nThanks for riding."},
+			),
+			want: []Report{{
+				Type:       TypeComplaint,
+				Recipient:  "rider@example.com",
+				MessageID:  "<abc123@ride.example.com>",
+				Diagnostic: "abuse",
+			}},
+		},
+		{
+			name: "complaint with redacted recipient",
+			message: report("feedback-report",
+				humanPart,
+				[2]string{"Content-Type: message/feedback-report",
+					"Feedback-Type: Fraud\r\n" +
+						"Version: 1"},
+				[2]string{"Content-Type: text/rfc822-headers", originalHeaders},
+			),
+			want: []Report{{
+				Type:       TypeComplaint,
+				Recipient:  "rider@example.com",
+				MessageID:  "<abc123@ride.example.com>",
+				Diagnostic: "fraud",
+			}},
+		},
+		{
+			name: "auto-reply",
+			message: "From: rider@example.com\r\n" +
+				"To: no-reply@ride.example.com\r\n" +
+				"Subject: Out of office\r\n" +
+				"Content-Type: text/plain\r\n\r\n" +
+				"I am away until Monday.\r\n",
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseMessage(strings.NewReader(tt.message))
+			if err != nil {
+				t.Fatalf("ParseMessage() error = %v", err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("ParseMessage() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseMessageInvalid(t *testing.T) {
+	if _, err := ParseMessage(strings.NewReader("not a message")); err == nil {
+		t.Error("ParseMessage() error = nil, want an error for a message without headers")
+	}
+}
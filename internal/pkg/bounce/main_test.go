@@ -0,0 +1,13 @@
+package bounce
+
+import (
+	"os"
+	"testing"
+
+	"ride-sharing-notification/internal/pkg/logging"
+)
+
+func TestMain(m *testing.M) {
+	logging.Discard()
+	os.Exit(m.Run())
+}
@@ -0,0 +1,137 @@
+package bounce
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+
+	"ride-sharing-notification/internal/pkg/logging"
+	"ride-sharing-notification/internal/pkg/storage"
+
+	"go.uber.org/zap"
+)
+
+// offsetBucket remembers how far each mailbox file has been read
+const offsetBucket = "bounce_mbox"
+
+// escapedFrom matches body lines quoted by mboxrd, e.g. ">From "
+var escapedFrom = regexp.MustCompile(`(?m)^>(>*From )`)
+
+// pollMbox processes the messages appended to the mailbox since the last
+// poll. A file that shrank was rotated and is read from the start.
+func (p *Processor) pollMbox(ctx context.Context) error {
+	offset, err := p.mboxOffset(ctx)
+	if err != nil {
+		return err
+	}
+	f, err := os.Open(p.mbox)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	if info.Size() < offset {
+		offset = 0
+	}
+	if info.Size() == offset {
+		return nil
+	}
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return err
+	}
+	data, err := io.ReadAll(f)
+	if err != nil {
+		return err
+	}
+
+	for _, m := range splitMbox(data) {
+		reports, err := ParseMessage(bytes.NewReader(unescapeMbox(data[m.start:m.end])))
+		if err != nil {
+			logging.GetLogger().Warn("skipping unreadable message in bounce mailbox", zap.Error(err))
+		}
+		for i := range reports {
+			reports[i].Source = SourceMbox
+		}
+		if err := p.ProcessAll(ctx, reports); err != nil {
+			// Picked up again from this message on the next poll
+			_ = p.saveMboxOffset(ctx, offset+int64(m.start))
+			return err
+		}
+		if err := p.saveMboxOffset(ctx, offset+int64(m.end)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// span is one message in a mailbox, from its "From " line to the next
+type span struct {
+	start, end int
+}
+
+// splitMbox finds the complete messages in data. A message starts with a
+// "From " line at the start of data or after a blank line; the last one is
+// only complete once it ends with a blank line, as it may still be being
+// written otherwise.
+func splitMbox(data []byte) []span {
+	var starts []int
+	if bytes.HasPrefix(data, []byte("From ")) {
+		starts = append(starts, 0)
+	}
+	for i := 0; ; {
+		j := bytes.Index(data[i:], []byte("\n\nFrom "))
+		if j < 0 {
+			break
+		}
+		starts = append(starts, i+j+2)
+		i += j + 2
+	}
+
+	var spans []span
+	for k, start := range starts {
+		if k+1 < len(starts) {
+			spans = append(spans, span{start, starts[k+1]})
+		} else if bytes.HasSuffix(data, []byte("\n\n")) {
+			spans = append(spans, span{start, len(data)})
+		}
+	}
+	return spans
+}
+
+// unescapeMbox drops the "From " envelope line and unquotes body lines
+func unescapeMbox(raw []byte) []byte {
+	if i := bytes.IndexByte(raw, '\n'); i >= 0 {
+		raw = raw[i+1:]
+	}
+	return escapedFrom.ReplaceAll(raw, []byte("$1"))
+}
+
+func (p *Processor) saveMboxOffset(ctx context.Context, offset int64) error {
+	return p.kv.Put(ctx, offsetBucket, p.mbox, []byte(strconv.FormatInt(offset, 10)))
+}
+
+func (p *Processor) mboxOffset(ctx context.Context) (int64, error) {
+	raw, err := p.kv.Get(ctx, offsetBucket, p.mbox)
+	if errors.Is(err, storage.ErrNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	offset, err := strconv.ParseInt(string(raw), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("decode mailbox offset: %w", err)
+	}
+	return offset, nil
+}
@@ -0,0 +1,164 @@
+package bounce
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"ride-sharing-notification/internal/pkg/email"
+	"ride-sharing-notification/internal/pkg/storage"
+	"ride-sharing-notification/internal/pkg/suppression"
+)
+
+func TestSplitMbox(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want []string
+	}{
+		{
+			name: "empty",
+			data: "",
+			want: nil,
+		},
+		{
+			name: "one complete message",
+			data: "From a@example.com Mon Jan  1 00:00:00 2024\nSubject: one\n\nbody\n\n",
+			want: []string{"From a@example.com Mon Jan  1 00:00:00 2024\nSubject: one\n\nbody\n\n"},
+		},
+		{
+			name: "last message still being written",
+			data: "From a Mon\nSubject: one\n\nbody\n\nFrom b Mon\nSubject: two\n\nbo",
+			want: []string{"From a Mon\nSubject: one\n\nbody\n\n"},
+		},
+		{
+			name: "two messages",
+			data: "From a Mon\nSubject: one\n\nbody\n\nFrom b Mon\nSubject: two\n\nbody\n\n",
+			want: []string{
+				"From a Mon\nSubject: one\n\nbody\n\n",
+				"From b Mon\nSubject: two\n\nbody\n\n",
+			},
+		},
+		{
+			name: "From inside a body line",
+			data: "From a Mon\nSubject: one\n\nsent From my phone\n>From the desk of\n\n",
+			want: []string{"From a Mon\nSubject: one\n\nsent From my phone\n>From the desk of\n\n"},
+		},
+		{
+			name: "no envelope line",
+			data: "Subject: one\n\nbody\n\n",
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, s := range splitMbox([]byte(tt.data)) {
+				got = append(got, tt.data[s.start:s.end])
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("splitMbox() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnescapeMbox(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		want string
+	}{
+		{"envelope dropped", "From a Mon\nSubject: x\n\nbody\n", "Subject: x\n\nbody\n"},
+		{"quoted From", "From a Mon\n\n>From here\n>>From there\n", "\nFrom here\n>From there\n"},
+		{"other quotes kept", "From a Mon\n\n> quoted reply\n", "\n> quoted reply\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := string(unescapeMbox([]byte(tt.raw))); got != tt.want {
+				t.Errorf("unescapeMbox() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// mboxBounce is a mailbox entry for a hard bounce to recipient
+func mboxBounce(recipient string) string {
+	dsn := report("delivery-status",
+		humanPart,
+		[2]string{"Content-Type: message/delivery-status",
+			"Reporting-MTA: dns; mx.example.com\r\n\r\n" +
+				"Final-Recipient: rfc822; " + recipient + "\r\n" +
+				"Action: failed\r\n" +
+				"Status: 5.1.1"},
+	)
+	return "From MAILER-DAEMON Mon Jan  1 00:00:00 2024\n" + strings.ReplaceAll(dsn, "\r\n", "\n") + "\n"
+}
+
+func TestPollMbox(t *testing.T) {
+	ctx := context.Background()
+	kv := storage.NewMemory()
+	suppressions := suppression.NewList(kv)
+	path := filepath.Join(t.TempDir(), "bounces")
+	messages := email.NewMessageLog(kv, time.Hour)
+	p := NewProcessor(messages, suppressions, kv, path)
+	for i, to := range []string{"a@example.com", "b@example.com", "c@example.com"} {
+		if err := messages.Record(ctx, email.Message{ID: fmt.Sprintf("<%d@ride.example.com>", i), To: to}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	suppressed := func(recipient string) bool {
+		t.Helper()
+		_, err := suppressions.Get(ctx, suppression.KindEmail, recipient)
+		return err == nil
+	}
+
+	// A missing mailbox is not an error
+	if err := p.pollMbox(ctx); err != nil {
+		t.Fatalf("pollMbox() without mailbox error = %v", err)
+	}
+
+	third := mboxBounce("c@example.com")
+	data := mboxBounce("a@example.com") + mboxBounce("b@example.com") + third[:len(third)/2]
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.pollMbox(ctx); err != nil {
+		t.Fatalf("pollMbox() error = %v", err)
+	}
+	if !suppressed("a@example.com") || !suppressed("b@example.com") {
+		t.Error("complete messages were not processed")
+	}
+	if suppressed("c@example.com") {
+		t.Error("partial message was processed")
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.WriteString(third[len(third)/2:]); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+	if err := p.pollMbox(ctx); err != nil {
+		t.Fatalf("pollMbox() error = %v", err)
+	}
+	if !suppressed("c@example.com") {
+		t.Error("completed message was not processed")
+	}
+
+	offset, err := p.mboxOffset(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := int64(len(data) + len(third) - len(third)/2); offset != want {
+		t.Errorf("offset = %d, want %d", offset, want)
+	}
+}
@@ -0,0 +1,164 @@
+package bounce
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// snsEnvelope is an Amazon SNS HTTP message
+type snsEnvelope struct {
+	Type         string `json:"Type"`
+	Message      string `json:"Message"`
+	SubscribeURL string `json:"SubscribeURL"`
+}
+
+// sesNotification is an Amazon SES bounce or complaint notification
+type sesNotification struct {
+	NotificationType string `json:"notificationType"`
+	// EventType replaces NotificationType in SES event publishing
+	EventType string `json:"eventType"`
+	Bounce    struct {
+		BounceType        string `json:"bounceType"`
+		BouncedRecipients []struct {
+			EmailAddress   string `json:"emailAddress"`
+			Status         string `json:"status"`
+			DiagnosticCode string `json:"diagnosticCode"`
+		} `json:"bouncedRecipients"`
+	} `json:"bounce"`
+	Complaint struct {
+		ComplaintFeedbackType string `json:"complaintFeedbackType"`
+		ComplainedRecipients  []struct {
+			EmailAddress string `json:"emailAddress"`
+		} `json:"complainedRecipients"`
+	} `json:"complaint"`
+	Mail struct {
+		MessageID string `json:"messageId"`
+		Headers   []struct {
+			Name  string `json:"name"`
+			Value string `json:"value"`
+		} `json:"headers"`
+	} `json:"mail"`
+}
+
+// ParseSES extracts the reports from an Amazon SES notification, posted
+// either through SNS or directly. For SNS subscription confirmations it
+// returns the URL to confirm with instead.
+func ParseSES(body []byte) (reports []Report, subscribeURL string, err error) {
+	var envelope snsEnvelope
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return nil, "", fmt.Errorf("decode notification: %w", err)
+	}
+	switch envelope.Type {
+	case "SubscriptionConfirmation":
+		return nil, envelope.SubscribeURL, nil
+	case "Notification":
+		body = []byte(envelope.Message)
+	case "UnsubscribeConfirmation":
+		return nil, "", nil
+	}
+
+	var n sesNotification
+	if err := json.Unmarshal(body, &n); err != nil {
+		return nil, "", fmt.Errorf("decode SES notification: %w", err)
+	}
+	// SES assigns its own ID, which is only used when the original
+	// Message-ID header is not included
+	messageID := n.Mail.MessageID
+	for _, h := range n.Mail.Headers {
+		if strings.EqualFold(h.Name, "Message-ID") {
+			messageID = h.Value
+		}
+	}
+
+	kind := n.NotificationType
+	if kind == "" {
+		kind = n.EventType
+	}
+	switch kind {
+	case "Bounce":
+		for _, r := range n.Bounce.BouncedRecipients {
+			reports = append(reports, Report{
+				Type:       TypeBounce,
+				Recipient:  r.EmailAddress,
+				MessageID:  messageID,
+				Permanent:  n.Bounce.BounceType == "Permanent",
+				Status:     r.Status,
+				Diagnostic: typedField(r.DiagnosticCode),
+				Source:     SourceSES,
+			})
+		}
+	case "Complaint":
+		feedbackType := n.Complaint.ComplaintFeedbackType
+		if feedbackType == "" {
+			feedbackType = "abuse"
+		}
+		for _, r := range n.Complaint.ComplainedRecipients {
+			reports = append(reports, Report{
+				Type:       TypeComplaint,
+				Recipient:  r.EmailAddress,
+				MessageID:  messageID,
+				Diagnostic: feedbackType,
+				Source:     SourceSES,
+			})
+		}
+	}
+	return reports, "", nil
+}
+
+// ParseJSON decodes reports posted in our own format, as one report or a
+// list of them
+func ParseJSON(body []byte) ([]Report, error) {
+	body = bytes.TrimSpace(body)
+	var reports []Report
+	if bytes.HasPrefix(body, []byte("[")) {
+		if err := json.Unmarshal(body, &reports); err != nil {
+			return nil, fmt.Errorf("decode reports: %w", err)
+		}
+	} else {
+		var r Report
+		if err := json.Unmarshal(body, &r); err != nil {
+			return nil, fmt.Errorf("decode report: %w", err)
+		}
+		reports = append(reports, r)
+	}
+	for i := range reports {
+		if reports[i].Type != TypeBounce && reports[i].Type != TypeComplaint {
+			return nil, fmt.Errorf("report %d: unknown type %q", i, reports[i].Type)
+		}
+		if reports[i].Recipient == "" && reports[i].MessageID == "" {
+			return nil, fmt.Errorf("report %d: recipient or message_id is required", i)
+		}
+		reports[i].Source = SourceWebhook
+	}
+	return reports, nil
+}
+
+// ConfirmSubscription confirms an SNS subscription. Only https URLs on
+// amazonaws.com are followed.
+func ConfirmSubscription(ctx context.Context, subscribeURL string) error {
+	u, err := url.Parse(subscribeURL)
+	if err != nil || u.Scheme != "https" || !strings.HasSuffix(u.Hostname(), ".amazonaws.com") {
+		return fmt.Errorf("refusing to confirm subscription at %q", subscribeURL)
+	}
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("confirm subscription: %w", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("confirm subscription: status %d", resp.StatusCode)
+	}
+	return nil
+}
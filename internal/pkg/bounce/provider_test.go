@@ -0,0 +1,194 @@
+package bounce
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"testing"
+)
+
+// snsNotification wraps an SES notification in an SNS envelope
+func snsNotification(t *testing.T, message string) string {
+	t.Helper()
+	body, err := json.Marshal(map[string]string{
+		"Type":      "Notification",
+		"MessageId": "22b80b92-fdea-4c2c-8f9d-bdfb0c7bf324",
+		"Message":   message,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(body)
+}
+
+const sesBounce = `{
+	"notificationType": "Bounce",
+	"bounce": {
+		"bounceType": "Permanent",
+		"bouncedRecipients": [
+			{"emailAddress": "rider@example.com", "status": "5.1.1", "diagnosticCode": "smtp; 550 5.1.1 user unknown"},
+			{"emailAddress": "driver@example.com", "status": "5.1.1"}
+		]
+	},
+	"mail": {
+		"messageId": "0100017f-ses-id",
+		"headers": [
+			{"name": "From", "value": "no-reply@ride.example.com"},
+			{"name": "Message-ID", "value": "<abc123@ride.example.com>"}
+		]
+	}
+}`
+
+func TestParseSES(t *testing.T) {
+	tests := []struct {
+		name          string
+		body          string
+		want          []Report
+		wantSubscribe string
+		wantErr       bool
+	}{
+		{
+			name: "bounce through SNS",
+			body: snsNotification(t, sesBounce),
+			want: []Report{
+				{Type: TypeBounce, Recipient: "rider@example.com", MessageID: "<abc123@ride.example.com>", Permanent: true, Status: "5.1.1", Diagnostic: "550 5.1.1 user unknown", Source: SourceSES},
+				{Type: TypeBounce, Recipient: "driver@example.com", MessageID: "<abc123@ride.example.com>", Permanent: true, Status: "5.1.1", Source: SourceSES},
+			},
+		},
+		{
+			name: "transient bounce posted directly",
+			body: `{"notificationType": "Bounce", "bounce": {"bounceType": "Transient",
+				"bouncedRecipients": [{"emailAddress": "rider@example.com"}]},
+				"mail": {"messageId": "0100017f-ses-id"}}`,
+			want: []Report{
+				{Type: TypeBounce, Recipient: "rider@example.com", MessageID: "0100017f-ses-id", Source: SourceSES},
+			},
+		},
+		{
+			name: "complaint from event publishing",
+			body: snsNotification(t, `{"eventType": "Complaint",
+				"complaint": {"complainedRecipients": [{"emailAddress": "rider@example.com"}]},
+				"mail": {"messageId": "0100017f-ses-id"}}`),
+			want: []Report{
+				{Type: TypeComplaint, Recipient: "rider@example.com", MessageID: "0100017f-ses-id", Diagnostic: "abuse", Source: SourceSES},
+			},
+		},
+		{
+			name: "complaint with feedback type",
+			body: `{"notificationType": "Complaint",
+				"complaint": {"complaintFeedbackType": "not-spam", "complainedRecipients": [{"emailAddress": "rider@example.com"}]},
+				"mail": {"messageId": "0100017f-ses-id"}}`,
+			want: []Report{
+				{Type: TypeComplaint, Recipient: "rider@example.com", MessageID: "0100017f-ses-id", Diagnostic: "not-spam", Source: SourceSES},
+			},
+		},
+		{
+			name: "delivery",
+			body: `{"notificationType": "Delivery", "mail": {"messageId": "0100017f-ses-id"}}`,
+			want: nil,
+		},
+		{
+			name: "subscription confirmation",
+			body: `{"Type": "SubscriptionConfirmation",
+				"SubscribeURL": "https://sns.us-east-1.amazonaws.com/?Action=ConfirmSubscription&Token=abc"}`,
+			wantSubscribe: "https://sns.us-east-1.amazonaws.com/?Action=ConfirmSubscription&Token=abc",
+		},
+		{
+			name: "unsubscribe confirmation",
+			body: `{"Type": "UnsubscribeConfirmation"}`,
+			want: nil,
+		},
+		{
+			name:    "not JSON",
+			body:    `<xml/>`,
+			wantErr: true,
+		},
+		{
+			name:    "SNS message not JSON",
+			body:    `{"Type": "Notification", "Message": "hello"}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, subscribeURL, err := ParseSES([]byte(tt.body))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseSES() error = %v, want error %v", err, tt.wantErr)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("ParseSES() reports = %+v, want %+v", got, tt.want)
+			}
+			if subscribeURL != tt.wantSubscribe {
+				t.Errorf("ParseSES() subscribeURL = %q, want %q", subscribeURL, tt.wantSubscribe)
+			}
+		})
+	}
+}
+
+func TestParseJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		want    []Report
+		wantErr bool
+	}{
+		{
+			name: "one report",
+			body: `{"type": "bounce", "recipient": "rider@example.com", "permanent": true, "status": "5.1.1", "source": "ignored"}`,
+			want: []Report{
+				{Type: TypeBounce, Recipient: "rider@example.com", Permanent: true, Status: "5.1.1", Source: SourceWebhook},
+			},
+		},
+		{
+			name: "list of reports",
+			body: ` [{"type": "complaint", "message_id": "<abc123@ride.example.com>"},
+				{"type": "bounce", "recipient": "driver@example.com"}]`,
+			want: []Report{
+				{Type: TypeComplaint, MessageID: "<abc123@ride.example.com>", Source: SourceWebhook},
+				{Type: TypeBounce, Recipient: "driver@example.com", Source: SourceWebhook},
+			},
+		},
+		{
+			name:    "unknown type",
+			body:    `{"type": "delivery", "recipient": "rider@example.com"}`,
+			wantErr: true,
+		},
+		{
+			name:    "no recipient or message ID",
+			body:    `[{"type": "bounce"}]`,
+			wantErr: true,
+		},
+		{
+			name:    "not JSON",
+			body:    `bounce`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseJSON([]byte(tt.body))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseJSON() error = %v, want error %v", err, tt.wantErr)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("ParseJSON() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConfirmSubscriptionRefusesOtherHosts(t *testing.T) {
+	for _, subscribeURL := range []string{
+		"http://sns.us-east-1.amazonaws.com/?Action=ConfirmSubscription",
+		"https://attacker.example.com/?Action=ConfirmSubscription",
+		"https://amazonaws.com.attacker.example.com/",
+		"https://169.254.169.254/latest/meta-data",
+		"::not a url",
+	} {
+		t.Run(subscribeURL, func(t *testing.T) {
+			if err := ConfirmSubscription(context.Background(), subscribeURL); err == nil {
+				t.Errorf("ConfirmSubscription(%q) error = nil, want refusal", subscribeURL)
+			}
+		})
+	}
+}
@@ -0,0 +1,206 @@
+package devices
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"ride-sharing-notification/internal/pkg/errors"
+	"ride-sharing-notification/internal/pkg/storage"
+)
+
+const (
+	// userBucket maps a user ID to the JSON list of that user's devices
+	userBucket = "devices"
+	// tokenBucket maps a device token to the user it is registered to
+	tokenBucket = "device_tokens"
+)
+
+// Platforms a device can be registered for
+const (
+	PlatformAndroid = "android"
+	PlatformIOS     = "ios"
+	PlatformWeb     = "web"
+)
+
+// Platforms lists every supported platform
+var Platforms = []string{PlatformAndroid, PlatformIOS, PlatformWeb}
+
+// Device is a push token registered to a user
+type Device struct {
+	Token        string    `json:"token"`
+	Platform     string    `json:"platform"`
+	AppVersion   string    `json:"app_version,omitempty"`
+	RegisteredAt time.Time `json:"registered_at"`
+	// LastSeen is refreshed every time the app registers the token again
+	LastSeen time.Time `json:"last_seen"`
+}
+
+// Registry keeps the device tokens of every user. A token belongs to at
+// most one user: registering it again for someone else moves it.
+type Registry struct {
+	kv storage.KV
+	// ttl is how long a device stays active without being seen; zero keeps
+	// devices active until they are unregistered or pruned
+	ttl time.Duration
+	now func() time.Time
+}
+
+func NewRegistry(kv storage.KV, ttl time.Duration) *Registry {
+	return &Registry{kv: kv, ttl: ttl, now: time.Now}
+}
+
+// Register adds the device to userID, or refreshes its platform, app
+// version and last-seen time when it is already registered. Invalid input
+// is returned as *errors.AppError.
+func (r *Registry) Register(ctx context.Context, userID string, device Device) (*Device, error) {
+	device.Token = strings.TrimSpace(device.Token)
+	device.Platform = strings.ToLower(device.Platform)
+	violations := map[string]string{}
+	if userID == "" {
+		violations["user_id"] = "user_id is required"
+	}
+	if device.Token == "" {
+		violations["token"] = "token is required"
+	}
+	if !slices.Contains(Platforms, device.Platform) {
+		violations["platform"] = fmt.Sprintf("platform must be one of %s", strings.Join(Platforms, ", "))
+	}
+	if len(violations) > 0 {
+		return nil, errors.NewValidationError("invalid device", violations)
+	}
+
+	// Take the token away from its previous owner first so a token is never
+	// fanned out to two users
+	owner, err := r.owner(ctx, device.Token)
+	if err != nil {
+		return nil, err
+	}
+	if owner != "" && owner != userID {
+		if err := r.removeFromUser(ctx, owner, device.Token); err != nil {
+			return nil, err
+		}
+	}
+
+	now := r.now().UTC()
+	var registered Device
+	err = r.updateUser(ctx, userID, func(devices []Device) []Device {
+		i := slices.IndexFunc(devices, func(d Device) bool { return d.Token == device.Token })
+		if i < 0 {
+			device.RegisteredAt = now
+			devices = append(devices, device)
+			i = len(devices) - 1
+		} else {
+			devices[i].Platform = device.Platform
+			devices[i].AppVersion = device.AppVersion
+		}
+		devices[i].LastSeen = now
+		registered = devices[i]
+		return devices
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := r.kv.Put(ctx, tokenBucket, device.Token, []byte(userID)); err != nil {
+		return nil, fmt.Errorf("index device token: %w", err)
+	}
+	return &registered, nil
+}
+
+// Unregister removes token from userID. Unknown tokens are ignored.
+func (r *Registry) Unregister(ctx context.Context, userID, token string) error {
+	owner, err := r.owner(ctx, token)
+	if err != nil {
+		return err
+	}
+	if err := r.removeFromUser(ctx, userID, token); err != nil {
+		return err
+	}
+	if owner == userID {
+		return r.kv.Delete(ctx, tokenBucket, token)
+	}
+	return nil
+}
+
+// Remove drops token from whichever user it is registered to. It is used
+// to prune tokens the push provider reports as unregistered.
+func (r *Registry) Remove(ctx context.Context, token string) error {
+	owner, err := r.owner(ctx, token)
+	if err != nil || owner == "" {
+		return err
+	}
+	return r.Unregister(ctx, owner, token)
+}
+
+// List returns every device registered to userID, including inactive ones
+func (r *Registry) List(ctx context.Context, userID string) ([]Device, error) {
+	var devices []Device
+	err := storage.GetJSON(ctx, r.kv, userBucket, userID, &devices)
+	if err != nil && !stderrors.Is(err, storage.ErrNotFound) {
+		return nil, fmt.Errorf("load devices: %w", err)
+	}
+	return devices, nil
+}
+
+// Tokens returns the tokens of userID's active devices
+func (r *Registry) Tokens(ctx context.Context, userID string) ([]string, error) {
+	devices, err := r.List(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	var tokens []string
+	for _, d := range devices {
+		if r.Active(d) {
+			tokens = append(tokens, d.Token)
+		}
+	}
+	return tokens, nil
+}
+
+// Active reports whether d was seen within the registry's TTL
+func (r *Registry) Active(d Device) bool {
+	return r.ttl <= 0 || r.now().Sub(d.LastSeen) <= r.ttl
+}
+
+// owner returns the user token is registered to, or "" if none
+func (r *Registry) owner(ctx context.Context, token string) (string, error) {
+	raw, err := r.kv.Get(ctx, tokenBucket, token)
+	if stderrors.Is(err, storage.ErrNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("look up device token: %w", err)
+	}
+	return string(raw), nil
+}
+
+func (r *Registry) removeFromUser(ctx context.Context, userID, token string) error {
+	return r.updateUser(ctx, userID, func(devices []Device) []Device {
+		return slices.DeleteFunc(devices, func(d Device) bool { return d.Token == token })
+	})
+}
+
+// updateUser atomically rewrites userID's device list
+func (r *Registry) updateUser(ctx context.Context, userID string, fn func([]Device) []Device) error {
+	err := r.kv.Update(ctx, userBucket, userID, func(current []byte) ([]byte, error) {
+		var devices []Device
+		if current != nil {
+			if err := json.Unmarshal(current, &devices); err != nil {
+				return nil, fmt.Errorf("decode devices: %w", err)
+			}
+		}
+		devices = fn(devices)
+		if len(devices) == 0 {
+			return nil, nil
+		}
+		return json.Marshal(devices)
+	})
+	if err != nil {
+		return fmt.Errorf("store devices: %w", err)
+	}
+	return nil
+}
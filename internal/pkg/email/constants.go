@@ -4,12 +4,38 @@ const (
 	EmailTypeRegister       = "USER_REGISTER"
 	EmailTypeForgetPassword = "FORGET_PASSWORD"
 	EmailTypeResetPassword  = "RESET_PASSWORD"
+
+	// Ride lifecycle
+	EmailTypeRideRequested  = "RIDE_REQUESTED"
+	EmailTypeDriverAssigned = "DRIVER_ASSIGNED"
+	EmailTypeDriverArriving = "DRIVER_ARRIVING"
+	EmailTypeTripStarted    = "TRIP_STARTED"
+	EmailTypeTripCompleted  = "TRIP_COMPLETED"
+	EmailTypeRideCancelled  = "RIDE_CANCELLED"
+	EmailTypePaymentFailed  = "PAYMENT_FAILED"
+	EmailTypeTripReceipt    = "TRIP_RECEIPT"
+
+	// Driver account
+	EmailTypeDriverPayout     = "DRIVER_PAYOUT"
+	EmailTypeDriverRating     = "DRIVER_RATING"
+	EmailTypeDocumentReminder = "DOCUMENT_REMINDER"
+
+	// EmailTypeDigest combines notifications batched over a window
+	EmailTypeDigest = "DIGEST"
 )
 
 type EmailPayload struct {
-	To         string
-	EMAIL_TYPE string
-	Data       map[string]interface{}
+	To          string
+	EMAIL_TYPE  string
+	Data        map[string]interface{}
+	Attachments []Attachment
+}
+
+// Attachment is a file sent along with the HTML body
+type Attachment struct {
+	Filename    string
+	ContentType string
+	Content     []byte
 }
 
 type EmailTemplate struct {
@@ -34,4 +60,68 @@ var EmailTemplates = map[string]EmailTemplate{
 		TemplateFile:   "internal/pkg/email/templates/reset_password.html",
 		RequiredFields: []string{"name"},
 	},
+	EmailTypeRideRequested: {
+		Subject:        "We're finding you a driver",
+		TemplateFile:   "internal/pkg/email/templates/ride_requested.html",
+		RequiredFields: []string{"name", "pickup", "dropoff"},
+	},
+	EmailTypeDriverAssigned: {
+		Subject:        "Your driver is on the way",
+		TemplateFile:   "internal/pkg/email/templates/driver_assigned.html",
+		RequiredFields: []string{"name", "driver_name", "vehicle", "plate", "eta_minutes"},
+	},
+	EmailTypeDriverArriving: {
+		Subject:        "Your driver is arriving",
+		TemplateFile:   "internal/pkg/email/templates/driver_arriving.html",
+		RequiredFields: []string{"name", "driver_name", "vehicle", "plate", "eta_minutes"},
+	},
+	EmailTypeTripStarted: {
+		Subject:        "Your trip has started",
+		TemplateFile:   "internal/pkg/email/templates/trip_started.html",
+		RequiredFields: []string{"name", "driver_name", "dropoff"},
+	},
+	EmailTypeTripCompleted: {
+		Subject:        "Thanks for riding with us",
+		TemplateFile:   "internal/pkg/email/templates/trip_completed.html",
+		RequiredFields: []string{"name", "fare", "dropoff"},
+	},
+	// fee is optional; it is only mentioned when present
+	EmailTypeRideCancelled: {
+		Subject:        "Your ride was cancelled",
+		TemplateFile:   "internal/pkg/email/templates/ride_cancelled.html",
+		RequiredFields: []string{"name", "cancelled_by"},
+	},
+	EmailTypePaymentFailed: {
+		Subject:        "Payment failed for your ride",
+		TemplateFile:   "internal/pkg/email/templates/payment_failed.html",
+		RequiredFields: []string{"name", "amount"},
+	},
+	// receipt is a receipt.View
+	EmailTypeTripReceipt: {
+		Subject:        "Your trip receipt",
+		TemplateFile:   "internal/pkg/email/templates/trip_receipt.html",
+		RequiredFields: []string{"receipt"},
+	},
+	EmailTypeDriverPayout: {
+		Subject:        "Your payout is on its way",
+		TemplateFile:   "internal/pkg/email/templates/driver_payout.html",
+		RequiredFields: []string{"name", "amount"},
+	},
+	// comment is optional; it is only shown when present
+	EmailTypeDriverRating: {
+		Subject:        "You received a new rating",
+		TemplateFile:   "internal/pkg/email/templates/driver_rating.html",
+		RequiredFields: []string{"name", "rating"},
+	},
+	EmailTypeDocumentReminder: {
+		Subject:        "A document is about to expire",
+		TemplateFile:   "internal/pkg/email/templates/document_reminder.html",
+		RequiredFields: []string{"name", "document", "expires_on"},
+	},
+	// items lists the batched notifications; name is optional
+	EmailTypeDigest: {
+		Subject:        "Your Ride Sharing updates",
+		TemplateFile:   "internal/pkg/email/templates/digest.html",
+		RequiredFields: []string{"items", "count"},
+	},
 }
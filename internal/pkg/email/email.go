@@ -3,28 +3,69 @@ package email
 import (
 	"bytes"
 	"context"
+	"encoding/base64"
+	"errors"
 	"fmt"
 	"html/template"
+	"mime"
+	"mime/multipart"
 	"net/smtp"
+	"net/textproto"
 	"path/filepath"
 	"ride-sharing-notification/config"
+	"ride-sharing-notification/internal/pkg/logging"
+	"ride-sharing-notification/internal/pkg/metrics"
+	"ride-sharing-notification/internal/pkg/response"
+	"ride-sharing-notification/internal/pkg/suppression"
+	"ride-sharing-notification/internal/pkg/throttle"
+	"ride-sharing-notification/internal/pkg/tracing"
 	"ride-sharing-notification/internal/proto/notification"
+	"sync/atomic"
 	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
 )
 
 const (
-	maxRetries      = 3
-	retryDelay      = 1 * time.Second
-	timeoutDuration = 10 * time.Second
+	// ThrottleKey is the throttle bucket shared by all SMTP sends
+	ThrottleKey = "email"
+
+	channelName = "email"
+)
+
+var (
+	// ErrDisabled is returned when the email channel is switched off
+	ErrDisabled = errors.New("email channel is disabled")
+	// ErrUnknownType is returned for a type without a template
+	ErrUnknownType = errors.New("unknown email type")
+	// ErrMissingField is returned when template data lacks a required field
+	ErrMissingField = errors.New("missing required field")
 )
 
 type Service struct {
 	config      *config.Config
 	auth        smtp.Auth
+	throttler   *throttle.Throttler
 	templateDir string
+	messages    *MessageLog
+	// suppressions are addresses that are never sent to
+	suppressions *suppression.List
+	settings     atomic.Pointer[settings]
 }
 
-func NewService(cfg *config.Config) *Service {
+// settings are the values that can change while the service is running
+type settings struct {
+	enabled     bool
+	maxAttempts int
+	retryDelay  time.Duration
+}
+
+// NewService sends through the configured SMTP server and records every
+// accepted email in messages so bounces can be matched to it. Addresses on
+// suppressions are skipped.
+func NewService(cfg *config.Config, throttler *throttle.Throttler, messages *MessageLog, suppressions *suppression.List) *Service {
 	auth := smtp.PlainAuth(
 		"",
 		cfg.Email.Username,
@@ -32,22 +73,79 @@ func NewService(cfg *config.Config) *Service {
 		cfg.Email.SMTPHost,
 	)
 
-	return &Service{
-		config: cfg,
-		auth:   auth,
+	s := &Service{
+		config:       cfg,
+		auth:         auth,
+		throttler:    throttler,
+		messages:     messages,
+		suppressions: suppressions,
+	}
+	s.ApplyConfig(cfg)
+	return s
+}
+
+// ApplyConfig switches the channel on or off and updates the retry policy
+// for sends that start afterwards
+func (s *Service) ApplyConfig(cfg *config.Config) {
+	maxAttempts := cfg.Email.Retry.MaxAttempts
+	if maxAttempts < 1 {
+		maxAttempts = 1
 	}
+	s.settings.Store(&settings{
+		enabled:     cfg.Email.Enabled,
+		maxAttempts: maxAttempts,
+		retryDelay:  cfg.Email.Retry.Delay,
+	})
+}
+
+// Enabled reports whether emails are currently being sent
+func (s *Service) Enabled() bool {
+	return s.settings.Load().enabled
 }
 
-func (s *Service) VerifyEmail(ctx context.Context, req *EmailPayload) (*notification.StandardResponse, error) {
+func (s *Service) VerifyEmail(ctx context.Context, req *EmailPayload) (resp *notification.StandardResponse, err error) {
+	start := time.Now()
+	ctx, span := tracing.Start(ctx, "email.send",
+		trace.WithAttributes(attribute.String("notification.type", req.EMAIL_TYPE)),
+	)
+	defer func() {
+		tracing.RecordError(span, err)
+		span.End()
+
+		status := metrics.StatusSent
+		switch {
+		case errors.Is(err, ErrDisabled), errors.Is(err, suppression.ErrSuppressed):
+			status = metrics.StatusSkipped
+		case err != nil:
+			status = metrics.StatusFailed
+		}
+		metrics.SendsTotal.WithLabelValues(channelName, req.EMAIL_TYPE, status).Inc()
+		metrics.SendDuration.WithLabelValues(channelName, req.EMAIL_TYPE).Observe(time.Since(start).Seconds())
+	}()
+
+	current := s.settings.Load()
+	if !current.enabled {
+		return nil, ErrDisabled
+	}
+	if err := s.suppressions.Check(ctx, suppression.KindEmail, req.To); err != nil {
+		return nil, err
+	}
+
 	// Fetch the template config
 	templateConfig, exists := EmailTemplates[req.EMAIL_TYPE]
 	if !exists {
-		return nil, fmt.Errorf("unknown email type: %s", req.EMAIL_TYPE)
+		return nil, fmt.Errorf("%w: %s", ErrUnknownType, req.EMAIL_TYPE)
+	}
+	for _, field := range templateConfig.RequiredFields {
+		if _, ok := req.Data[field]; !ok {
+			return nil, fmt.Errorf("%w %q for %s", ErrMissingField, field, req.EMAIL_TYPE)
+		}
 	}
 
 	// Render the HTML body with dynamic data
-	body, err := s.renderTemplate(templateConfig.TemplateFile, req.Data)
+	body, err := s.renderTemplate(ctx, templateConfig.TemplateFile, req.Data)
 	if err != nil {
+		metrics.TemplateRenderErrorsTotal.WithLabelValues(req.EMAIL_TYPE).Inc()
 		return nil, fmt.Errorf("failed to render template: %w", err)
 	}
 
@@ -58,10 +156,12 @@ func (s *Service) VerifyEmail(ctx context.Context, req *EmailPayload) (*notifica
 	}
 
 	// Construct the email
+	messageID := newMessageID(from)
 	message := fmt.Sprintf(
 		"From: %s\r\n"+
 			"To: %s\r\n"+
 			"Subject: %s\r\n"+
+			"Message-ID: <%s>\r\n"+
 			"MIME-version: 1.0;\r\n"+
 			"Content-Type: text/html; charset=\"UTF-8\";\r\n"+
 			"\r\n"+
@@ -69,50 +169,156 @@ func (s *Service) VerifyEmail(ctx context.Context, req *EmailPayload) (*notifica
 		from,
 		req.To,
 		templateConfig.Subject,
+		messageID,
 		body,
 	)
+	if len(req.Attachments) > 0 {
+		message, err = multipartMessage(from, req.To, templateConfig.Subject, messageID, body, req.Attachments)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	// Retry logic
 	var lastErr error
-	for attempt := 1; attempt <= maxRetries; attempt++ {
-		ctx, cancel := context.WithTimeout(ctx, timeoutDuration)
+	for attempt := 1; attempt <= current.maxAttempts; attempt++ {
+		if attempt > 1 {
+			metrics.SendRetriesTotal.WithLabelValues(channelName, req.EMAIL_TYPE).Inc()
+		}
+
+		// Queue behind the provider's throughput limit before each attempt
+		waited, err := s.throttler.Wait(ctx, ThrottleKey)
+		metrics.ThrottleWait.WithLabelValues(ThrottleKey).Observe(waited.Seconds())
+		if err != nil {
+			return nil, fmt.Errorf("waiting for send slot: %w", err)
+		}
+
+		ctx, cancel := context.WithTimeout(ctx, s.config.Email.Timeout)
 		defer cancel()
 
-		err := s.sendEmail(ctx, from, req.To, []byte(message))
+		err = s.sendEmail(ctx, from, req.To, []byte(message))
 		if err == nil {
-			return &notification.StandardResponse{
-				Success: true,
-				Message: "Email sent successfully",
-			}, nil
+			s.record(ctx, messageID, req)
+			return response.New().
+				Success().
+				WithMessage("Email sent successfully").
+				WithData(&notification.EmailResult{MessageId: messageID}, nil)
 		}
 		lastErr = err
-		if attempt < maxRetries {
-			time.Sleep(retryDelay)
+		if attempt < current.maxAttempts {
+			time.Sleep(current.retryDelay)
 		}
 	}
 
-	return nil, fmt.Errorf("after %d attempts, last error: %w", maxRetries, lastErr)
+	return nil, fmt.Errorf("after %d attempts, last error: %w", current.maxAttempts, lastErr)
+}
+
+// record remembers a sent email. The email is out either way, so a failure
+// only costs matching its bounces and is logged.
+func (s *Service) record(ctx context.Context, messageID string, req *EmailPayload) {
+	if s.messages == nil {
+		return
+	}
+	err := s.messages.Record(context.WithoutCancel(ctx), Message{
+		ID:   messageID,
+		To:   req.To,
+		Type: req.EMAIL_TYPE,
+	})
+	if err != nil {
+		logging.GetLogger().WithContext(ctx).Warn("failed to record sent email",
+			zap.String("message_id", messageID), zap.Error(err))
+	}
 }
 
-func (s *Service) renderTemplate(templateFile string, data interface{}) (string, error) {
+func (s *Service) renderTemplate(ctx context.Context, templateFile string, data interface{}) (string, error) {
+	_, span := tracing.Start(ctx, "email.render_template",
+		trace.WithAttributes(attribute.String("template.file", templateFile)),
+	)
+	defer span.End()
+
 	templatePath := filepath.Join(s.templateDir, templateFile)
 	tmpl, err := template.ParseFiles(templatePath)
 	if err != nil {
+		tracing.RecordError(span, err)
 		return "", fmt.Errorf("failed to parse template: %w", err)
 	}
 
 	var buf bytes.Buffer
 	if err := tmpl.Execute(&buf, data); err != nil {
+		tracing.RecordError(span, err)
 		return "", fmt.Errorf("failed to execute template: %w", err)
 	}
 
 	return buf.String(), nil
 }
 
-func (s *Service) sendEmail(ctx context.Context, from, to string, message []byte) error {
+// multipartMessage builds a multipart/mixed message with the HTML body
+// followed by base64-encoded attachments
+func multipartMessage(from, to, subject, messageID, body string, attachments []Attachment) (string, error) {
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	fmt.Fprintf(&buf,
+		"From: %s\r\n"+
+			"To: %s\r\n"+
+			"Subject: %s\r\n"+
+			"Message-ID: <%s>\r\n"+
+			"MIME-version: 1.0;\r\n"+
+			"Content-Type: multipart/mixed; boundary=%q\r\n"+
+			"\r\n",
+		from, to, subject, messageID, w.Boundary(),
+	)
+
+	part, err := w.CreatePart(textproto.MIMEHeader{
+		"Content-Type": {`text/html; charset="UTF-8"`},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to build message: %w", err)
+	}
+	part.Write([]byte(body))
+
+	for _, a := range attachments {
+		part, err := w.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {a.ContentType},
+			"Content-Transfer-Encoding": {"base64"},
+			"Content-Disposition":       {mime.FormatMediaType("attachment", map[string]string{"filename": a.Filename})},
+		})
+		if err != nil {
+			return "", fmt.Errorf("failed to build message: %w", err)
+		}
+		// RFC 2045 limits encoded lines to 76 characters
+		encoded := base64.StdEncoding.EncodeToString(a.Content)
+		for len(encoded) > 76 {
+			part.Write([]byte(encoded[:76] + "\r\n"))
+			encoded = encoded[76:]
+		}
+		part.Write([]byte(encoded + "\r\n"))
+	}
+	if err := w.Close(); err != nil {
+		return "", fmt.Errorf("failed to build message: %w", err)
+	}
+	return buf.String(), nil
+}
+
+func (s *Service) sendEmail(ctx context.Context, from, to string, message []byte) (err error) {
+	ctx, span := tracing.Start(ctx, "email.smtp_send",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("notification.channel", channelName),
+			attribute.String("provider", "smtp"),
+			attribute.String("server.address", s.config.Email.SMTPHost),
+		),
+	)
+	defer func() {
+		tracing.RecordError(span, err)
+		span.End()
+	}()
+
 	done := make(chan error, 1)
 
 	go func() {
+		metrics.SMTPSessionsActive.Inc()
+		defer metrics.SMTPSessionsActive.Dec()
+
 		done <- smtp.SendMail(
 			s.config.Email.SMTPHost+":"+s.config.Email.SMTPPort,
 			s.auth,
@@ -124,8 +330,14 @@ func (s *Service) sendEmail(ctx context.Context, from, to string, message []byte
 
 	select {
 	case err := <-done:
+		result := "ok"
+		if err != nil {
+			result = "error"
+		}
+		metrics.SMTPSessionsTotal.WithLabelValues(result).Inc()
 		return err
 	case <-ctx.Done():
+		metrics.SMTPSessionsTotal.WithLabelValues("timeout").Inc()
 		return ctx.Err()
 	}
 }
@@ -0,0 +1,172 @@
+package email
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/mail"
+	"strings"
+	"time"
+
+	"ride-sharing-notification/internal/pkg/storage"
+
+	"github.com/google/uuid"
+)
+
+const (
+	messageBucket = "email_messages"
+	// recipientBucket maps a recipient to the ID of the last email sent to it
+	recipientBucket = "email_recipients"
+)
+
+// Statuses of a sent email
+const (
+	StatusSent = "sent"
+	// StatusBounced is a permanent delivery failure
+	StatusBounced     = "bounced"
+	StatusSoftBounced = "soft_bounced"
+	StatusComplained  = "complained"
+)
+
+// ErrMessageNotFound is returned for unknown or expired message IDs
+var ErrMessageNotFound = errors.New("email message not found")
+
+// Message is an email the SMTP server accepted, and what became of it
+type Message struct {
+	ID     string `json:"id"`
+	To     string `json:"to"`
+	Type   string `json:"type"`
+	Status string `json:"status"`
+	// Reason explains a bounce or complaint
+	Reason    string    `json:"reason,omitempty"`
+	SentAt    time.Time `json:"sent_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// MessageLog remembers sent emails for a while so bounce and complaint
+// reports can be matched to them
+type MessageLog struct {
+	kv        storage.KV
+	retention time.Duration
+	now       func() time.Time
+}
+
+// NewMessageLog keeps messages for retention; zero keeps them forever
+func NewMessageLog(kv storage.KV, retention time.Duration) *MessageLog {
+	return &MessageLog{kv: kv, retention: retention, now: time.Now}
+}
+
+// Record stores a message that was just sent
+func (l *MessageLog) Record(ctx context.Context, m Message) error {
+	now := l.now().UTC()
+	m.ID = NormalizeMessageID(m.ID)
+	m.Status = StatusSent
+	m.SentAt = now
+	m.UpdatedAt = now
+	if err := storage.PutJSON(ctx, l.kv, messageBucket, m.ID, &m); err != nil {
+		return fmt.Errorf("record email message: %w", err)
+	}
+	return l.kv.Put(ctx, recipientBucket, strings.ToLower(m.To), []byte(m.ID))
+}
+
+// Get returns the message with id
+func (l *MessageLog) Get(ctx context.Context, id string) (*Message, error) {
+	m := &Message{}
+	if err := storage.GetJSON(ctx, l.kv, messageBucket, NormalizeMessageID(id), m); err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, ErrMessageNotFound
+		}
+		return nil, err
+	}
+	return m, nil
+}
+
+// LastTo returns the last message sent to the address
+func (l *MessageLog) LastTo(ctx context.Context, to string) (*Message, error) {
+	id, err := l.kv.Get(ctx, recipientBucket, strings.ToLower(strings.TrimSpace(to)))
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, ErrMessageNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return l.Get(ctx, string(id))
+}
+
+// SetStatus records what became of the message with id
+func (l *MessageLog) SetStatus(ctx context.Context, id, status, reason string) (*Message, error) {
+	var updated *Message
+	err := l.kv.Update(ctx, messageBucket, NormalizeMessageID(id), func(current []byte) ([]byte, error) {
+		if current == nil {
+			return nil, ErrMessageNotFound
+		}
+		m := &Message{}
+		if err := json.Unmarshal(current, m); err != nil {
+			return nil, fmt.Errorf("decode email message %s: %w", id, err)
+		}
+		m.Status = status
+		m.Reason = reason
+		m.UpdatedAt = l.now().UTC()
+		updated = m
+		return json.Marshal(m)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// Expire deletes messages sent longer than the retention ago and returns
+// how many
+func (l *MessageLog) Expire(ctx context.Context) (int, error) {
+	if l.retention <= 0 {
+		return 0, nil
+	}
+	cutoff := l.now().Add(-l.retention)
+	var stale []Message
+	err := l.kv.Scan(ctx, messageBucket, "", func(key string, value []byte) error {
+		var m Message
+		if err := json.Unmarshal(value, &m); err != nil {
+			return fmt.Errorf("decode email message %s: %w", key, err)
+		}
+		if m.SentAt.Before(cutoff) {
+			stale = append(stale, m)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	for i, m := range stale {
+		if err := l.kv.Delete(ctx, messageBucket, m.ID); err != nil {
+			return i, err
+		}
+		err := l.kv.Update(ctx, recipientBucket, strings.ToLower(m.To), func(current []byte) ([]byte, error) {
+			if string(current) == m.ID {
+				return nil, nil
+			}
+			return current, nil
+		})
+		if err != nil {
+			return i, err
+		}
+	}
+	return len(stale), nil
+}
+
+// NormalizeMessageID strips the angle brackets of a Message-ID header
+func NormalizeMessageID(id string) string {
+	return strings.Trim(strings.TrimSpace(id), "<>")
+}
+
+// newMessageID returns a Message-ID in the domain of the from address
+func newMessageID(from string) string {
+	domain := "localhost"
+	if addr, err := mail.ParseAddress(from); err == nil {
+		if at := strings.LastIndex(addr.Address, "@"); at >= 0 {
+			domain = addr.Address[at+1:]
+		}
+	}
+	return uuid.New().String() + "@" + domain
+}
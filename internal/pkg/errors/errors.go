@@ -2,9 +2,12 @@ package errors
 
 import (
 	"fmt"
+	"time"
 
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
 )
 
 type ErrorType string
@@ -16,14 +19,18 @@ const (
 	ErrorTypeNotFound     ErrorType = "NOT_FOUND_ERROR"
 	ErrorTypeUnauthorized ErrorType = "UNAUTHORIZED_ERROR"
 	ErrorTypeForbidden    ErrorType = "FORBIDDEN_ERROR"
+	ErrorTypeRateLimited  ErrorType = "RATE_LIMITED_ERROR"
+	ErrorTypeUnavailable  ErrorType = "UNAVAILABLE_ERROR"
+	ErrorTypeSuppressed   ErrorType = "SUPPRESSED_ERROR"
 	ErrorTypeInternal     ErrorType = "INTERNAL_ERROR"
 )
 
 type AppError struct {
-	Type    ErrorType
-	Message string
-	Details any
-	Err     error
+	Type       ErrorType
+	Message    string
+	Details    any
+	Err        error
+	RetryAfter time.Duration
 }
 
 func (e *AppError) Error() string {
@@ -49,6 +56,12 @@ func toGRPCCode(t ErrorType) codes.Code {
 		return codes.Unauthenticated
 	case ErrorTypeForbidden:
 		return codes.PermissionDenied
+	case ErrorTypeRateLimited:
+		return codes.ResourceExhausted
+	case ErrorTypeUnavailable:
+		return codes.Unavailable
+	case ErrorTypeSuppressed:
+		return codes.FailedPrecondition
 	default:
 		return codes.Internal
 	}
@@ -56,7 +69,29 @@ func toGRPCCode(t ErrorType) codes.Code {
 
 // Converts AppError to gRPC status
 func ToGRPCStatus(err *AppError) error {
-	return status.Error(toGRPCCode(err.Type), err.Message)
+	st := status.New(toGRPCCode(err.Type), err.Message)
+	if fields, ok := err.Details.(map[string]string); ok && len(fields) > 0 {
+		// Report each invalid field so clients can point at the exact input
+		violations := make([]*errdetails.BadRequest_FieldViolation, 0, len(fields))
+		for field, description := range fields {
+			violations = append(violations, &errdetails.BadRequest_FieldViolation{
+				Field:       field,
+				Description: description,
+			})
+		}
+		if detailed, detailErr := st.WithDetails(&errdetails.BadRequest{FieldViolations: violations}); detailErr == nil {
+			st = detailed
+		}
+	}
+	if err.RetryAfter > 0 {
+		// Tell well-behaved clients how long to back off before retrying
+		if detailed, detailErr := st.WithDetails(&errdetails.RetryInfo{
+			RetryDelay: durationpb.New(err.RetryAfter),
+		}); detailErr == nil {
+			st = detailed
+		}
+	}
+	return st.Err()
 }
 
 // Factory functions
@@ -103,6 +138,23 @@ func NewForbiddenError(message string) *AppError {
 	}
 }
 
+func NewUnavailableError(message string) *AppError {
+	return &AppError{
+		Type:    ErrorTypeUnavailable,
+		Message: message,
+	}
+}
+
+// NewSuppressedError reports a send skipped on purpose because the
+// recipient is on the suppression list. err lets callers match the cause.
+func NewSuppressedError(message string, err error) *AppError {
+	return &AppError{
+		Type:    ErrorTypeSuppressed,
+		Message: message,
+		Err:     err,
+	}
+}
+
 func NewInternalError(err error) *AppError {
 	return &AppError{
 		Type:    ErrorTypeInternal,
@@ -110,3 +162,11 @@ func NewInternalError(err error) *AppError {
 		Err:     err,
 	}
 }
+
+func NewRateLimitError(message string, retryAfter time.Duration) *AppError {
+	return &AppError{
+		Type:       ErrorTypeRateLimited,
+		Message:    message,
+		RetryAfter: retryAfter,
+	}
+}
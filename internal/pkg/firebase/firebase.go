@@ -1 +1,167 @@
 package firebase
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"ride-sharing-notification/config"
+)
+
+// Client sends push notifications through the Firebase Cloud Messaging
+// HTTP v1 API
+type Client struct {
+	projectID string
+	endpoint  string
+	tokens    *tokenSource
+	client    *http.Client
+}
+
+func NewClient(projectID, credentialsFile, endpoint string, timeout time.Duration) (*Client, error) {
+	httpClient := &http.Client{Timeout: timeout}
+	tokens, err := newTokenSource(credentialsFile, httpClient)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{
+		projectID: projectID,
+		endpoint:  strings.TrimRight(endpoint, "/"),
+		tokens:    tokens,
+		client:    httpClient,
+	}, nil
+}
+
+// NewFromConfig returns the FCM client, or nil when no project is configured
+func NewFromConfig(cfg *config.Config) (*Client, error) {
+	if cfg.Push.FCM.ProjectID == "" || cfg.Push.FCM.CredentialsFile == "" {
+		return nil, nil
+	}
+	return NewClient(cfg.Push.FCM.ProjectID, cfg.Push.FCM.CredentialsFile, cfg.Push.FCM.Endpoint, cfg.Push.Timeout)
+}
+
+func (c *Client) Name() string {
+	return "fcm"
+}
+
+type fcmRequest struct {
+	Message fcmMessage `json:"message"`
+}
+
+type fcmMessage struct {
+	Token        string            `json:"token"`
+	Notification *fcmNotification  `json:"notification,omitempty"`
+	Data         map[string]string `json:"data,omitempty"`
+}
+
+type fcmNotification struct {
+	Title string `json:"title,omitempty"`
+	Body  string `json:"body,omitempty"`
+}
+
+type fcmResponse struct {
+	Name  string `json:"name"`
+	Error struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+		Status  string `json:"status"`
+		Details []struct {
+			Type      string `json:"@type"`
+			ErrorCode string `json:"errorCode"`
+		} `json:"details"`
+	} `json:"error"`
+}
+
+// Send delivers a notification to one device and returns the FCM message name
+func (c *Client) Send(ctx context.Context, token, title, body string, data map[string]string) (string, error) {
+	payload, err := json.Marshal(fcmRequest{Message: fcmMessage{
+		Token:        token,
+		Notification: &fcmNotification{Title: title, Body: body},
+		Data:         data,
+	}})
+	if err != nil {
+		return "", err
+	}
+
+	accessToken, err := c.tokens.Token(ctx)
+	if err != nil {
+		return "", &Error{Message: err.Error()}
+	}
+
+	endpoint := fmt.Sprintf("%s/v1/projects/%s/messages:send", c.endpoint, url.PathEscape(c.projectID))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return "", &Error{Message: err.Error()}
+	}
+	defer resp.Body.Close()
+
+	var parsed fcmResponse
+	raw, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	_ = json.Unmarshal(raw, &parsed)
+
+	if resp.StatusCode != http.StatusOK {
+		fcmErr := &Error{
+			StatusCode: resp.StatusCode,
+			Status:     parsed.Error.Status,
+			Message:    parsed.Error.Message,
+		}
+		for _, detail := range parsed.Error.Details {
+			if detail.ErrorCode != "" {
+				fcmErr.ErrorCode = detail.ErrorCode
+			}
+		}
+		if fcmErr.Message == "" {
+			fcmErr.Message = http.StatusText(resp.StatusCode)
+		}
+		if resp.StatusCode == http.StatusUnauthorized {
+			// Fetch a fresh access token on the next send
+			c.tokens.Invalidate()
+		}
+		return "", fcmErr
+	}
+	return parsed.Name, nil
+}
+
+// Error is a failure reported by FCM
+type Error struct {
+	StatusCode int
+	Status     string
+	// ErrorCode is the FCM specific code, e.g. UNREGISTERED or QUOTA_EXCEEDED
+	ErrorCode string
+	Message   string
+}
+
+func (e *Error) Error() string {
+	if e.StatusCode == 0 {
+		return "fcm: " + e.Message
+	}
+	if e.ErrorCode != "" {
+		return fmt.Sprintf("fcm: %d %s (%s)", e.StatusCode, e.Message, e.ErrorCode)
+	}
+	return fmt.Sprintf("fcm: %d %s", e.StatusCode, e.Message)
+}
+
+// Unregistered reports whether the device token is no longer valid and
+// should be forgotten
+func (e *Error) Unregistered() bool {
+	return e.ErrorCode == "UNREGISTERED" || e.StatusCode == http.StatusNotFound
+}
+
+// Permanent reports whether retrying the same message cannot succeed
+func (e *Error) Permanent() bool {
+	return e.Unregistered() || e.ErrorCode == "INVALID_ARGUMENT" || e.ErrorCode == "SENDER_ID_MISMATCH" ||
+		e.StatusCode == http.StatusBadRequest || e.StatusCode == http.StatusForbidden
+}
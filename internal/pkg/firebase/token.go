@@ -0,0 +1,133 @@
+package firebase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	messagingScope  = "https://www.googleapis.com/auth/firebase.messaging"
+	defaultTokenURI = "https://oauth2.googleapis.com/token"
+	// Refresh access tokens this long before they expire
+	tokenExpiryMargin = time.Minute
+)
+
+type serviceAccount struct {
+	ClientEmail  string `json:"client_email"`
+	PrivateKeyID string `json:"private_key_id"`
+	PrivateKey   string `json:"private_key"`
+	TokenURI     string `json:"token_uri"`
+}
+
+// tokenSource exchanges a signed service account assertion for OAuth access
+// tokens and caches them until shortly before they expire
+type tokenSource struct {
+	account serviceAccount
+	key     any
+	client  *http.Client
+
+	mu      sync.Mutex
+	token   string
+	expires time.Time
+}
+
+func newTokenSource(credentialsFile string, client *http.Client) (*tokenSource, error) {
+	raw, err := os.ReadFile(credentialsFile)
+	if err != nil {
+		return nil, fmt.Errorf("read fcm credentials: %w", err)
+	}
+
+	var account serviceAccount
+	if err := json.Unmarshal(raw, &account); err != nil {
+		return nil, fmt.Errorf("parse fcm credentials: %w", err)
+	}
+	if account.ClientEmail == "" || account.PrivateKey == "" {
+		return nil, fmt.Errorf("fcm credentials must contain client_email and private_key")
+	}
+	if account.TokenURI == "" {
+		account.TokenURI = defaultTokenURI
+	}
+
+	key, err := jwt.ParseRSAPrivateKeyFromPEM([]byte(account.PrivateKey))
+	if err != nil {
+		return nil, fmt.Errorf("parse fcm private key: %w", err)
+	}
+
+	return &tokenSource{account: account, key: key, client: client}, nil
+}
+
+// Token returns a valid access token, fetching a new one when needed
+func (t *tokenSource) Token(ctx context.Context) (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.token != "" && time.Now().Before(t.expires.Add(-tokenExpiryMargin)) {
+		return t.token, nil
+	}
+
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"iss":   t.account.ClientEmail,
+		"scope": messagingScope,
+		"aud":   t.account.TokenURI,
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Hour).Unix(),
+	}
+	assertion := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	if t.account.PrivateKeyID != "" {
+		assertion.Header["kid"] = t.account.PrivateKeyID
+	}
+	signed, err := assertion.SignedString(t.key)
+	if err != nil {
+		return "", fmt.Errorf("sign token assertion: %w", err)
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "urn:ietf:params:oauth:grant-type:jwt-bearer")
+	form.Set("assertion", signed)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.account.TokenURI, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("fetch access token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	raw, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("fetch access token: %s: %s", resp.Status, strings.TrimSpace(string(raw)))
+	}
+
+	var parsed struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	if err := json.Unmarshal(raw, &parsed); err != nil || parsed.AccessToken == "" {
+		return "", fmt.Errorf("fetch access token: invalid response")
+	}
+
+	t.token = parsed.AccessToken
+	t.expires = now.Add(time.Duration(parsed.ExpiresIn) * time.Second)
+	return t.token, nil
+}
+
+// Invalidate drops the cached token
+func (t *tokenSource) Invalidate() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.token = ""
+}
@@ -0,0 +1,367 @@
+package inbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"ride-sharing-notification/config"
+	"ride-sharing-notification/internal/pkg/logging"
+	"ride-sharing-notification/internal/pkg/storage"
+
+	"go.uber.org/zap"
+)
+
+const bucket = "inbox"
+
+// Filters for List
+const (
+	// FilterAll lists every item that is not archived
+	FilterAll      = "all"
+	FilterUnread   = "unread"
+	FilterArchived = "archived"
+)
+
+// Actions for Update
+const (
+	ActionRead      = "read"
+	ActionUnread    = "unread"
+	ActionArchive   = "archive"
+	ActionUnarchive = "unarchive"
+)
+
+var (
+	// ErrUnknownAction is returned for actions Update does not know
+	ErrUnknownAction = errors.New("unknown inbox action")
+
+	// errFound ends a scan once the item looked for is found
+	errFound = errors.New("found")
+)
+
+// Item is one notification in a user's inbox
+type Item struct {
+	ID         string            `json:"id"`
+	UserID     string            `json:"user_id"`
+	Type       string            `json:"type"`
+	Title      string            `json:"title"`
+	Body       string            `json:"body"`
+	Data       map[string]string `json:"data,omitempty"`
+	CreatedAt  time.Time         `json:"created_at"`
+	ReadAt     *time.Time        `json:"read_at,omitempty"`
+	ArchivedAt *time.Time        `json:"archived_at,omitempty"`
+	// Key identifies the notification the item was added for, if known
+	Key string `json:"key,omitempty"`
+}
+
+func (i *Item) Read() bool {
+	return i.ReadAt != nil
+}
+
+func (i *Item) Archived() bool {
+	return i.ArchivedAt != nil
+}
+
+// Service stores each user's inbox, newest first. Items expire a while
+// after they arrive, read items sooner, and only the newest MaxItems per
+// user are kept.
+type Service struct {
+	kv       storage.KV
+	ttl      time.Duration
+	readTTL  time.Duration
+	maxItems int
+	now      func() time.Time
+}
+
+// NewService keeps items for ttl, or readTTL once read. Zero durations
+// keep items until they are pushed out by maxItems.
+func NewService(kv storage.KV, ttl, readTTL time.Duration, maxItems int) *Service {
+	return &Service{
+		kv:       kv,
+		ttl:      ttl,
+		readTTL:  readTTL,
+		maxItems: maxItems,
+		now:      time.Now,
+	}
+}
+
+func NewFromConfig(kv storage.KV, cfg *config.Config) *Service {
+	return NewService(kv, cfg.Inbox.TTL, cfg.Inbox.ReadTTL, cfg.Inbox.MaxItems)
+}
+
+// Add stores item for item.UserID and returns it with its ID set. An item
+// with the Key of one already in the inbox is not added again; the stored
+// item is returned instead, so a retried notification shows up once.
+func (s *Service) Add(ctx context.Context, item Item) (*Item, error) {
+	if item.Key != "" {
+		existing, err := s.find(ctx, item.UserID, item.Key)
+		if err != nil {
+			return nil, err
+		}
+		if existing != nil {
+			return existing, nil
+		}
+	}
+
+	now := s.now().UTC()
+	item.CreatedAt = now
+	item.ReadAt = nil
+	item.ArchivedAt = nil
+	// IDs sort newest first so a prefix scan returns the inbox in order
+	item.ID = storage.NewestFirstID(now)
+	if err := storage.PutJSON(ctx, s.kv, bucket, key(item.UserID, item.ID), &item); err != nil {
+		return nil, fmt.Errorf("add inbox item: %w", err)
+	}
+	if err := s.trim(ctx, item.UserID); err != nil {
+		logging.GetLogger().WithContext(ctx).Warn("failed to trim inbox", zap.String("user_id", item.UserID), zap.Error(err))
+	}
+	return &item, nil
+}
+
+// List returns one page of the user's inbox and the number of items
+// matching filter. Pages start at 1.
+func (s *Service) List(ctx context.Context, userID, filter string, page, perPage int) ([]Item, int, error) {
+	items, err := s.items(ctx, userID)
+	if err != nil {
+		return nil, 0, err
+	}
+	matching := items[:0]
+	for _, item := range items {
+		if matches(&item, filter) {
+			matching = append(matching, item)
+		}
+	}
+
+	total := len(matching)
+	start := min((page-1)*perPage, total)
+	end := min(start+perPage, total)
+	return matching[start:end], total, nil
+}
+
+// UnreadCount counts unread items that are not archived
+func (s *Service) UnreadCount(ctx context.Context, userID string) (int, error) {
+	items, err := s.items(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+	count := 0
+	for _, item := range items {
+		if matches(&item, FilterUnread) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// Update applies action to the user's items with ids and returns how many
+// changed. Unknown and expired IDs are ignored.
+func (s *Service) Update(ctx context.Context, userID string, ids []string, action string) (int, error) {
+	switch action {
+	case ActionRead, ActionUnread, ActionArchive, ActionUnarchive:
+	default:
+		return 0, fmt.Errorf("%w: %q", ErrUnknownAction, action)
+	}
+
+	now := s.now().UTC()
+	updated := 0
+	for _, id := range ids {
+		err := s.kv.Update(ctx, bucket, key(userID, id), func(current []byte) ([]byte, error) {
+			if current == nil {
+				return nil, nil
+			}
+			var item Item
+			if err := json.Unmarshal(current, &item); err != nil {
+				return nil, fmt.Errorf("decode inbox item %s: %w", id, err)
+			}
+			if s.expired(&item, now) || !apply(&item, action, now) {
+				return current, nil
+			}
+			updated++
+			return json.Marshal(&item)
+		})
+		if err != nil {
+			return updated, err
+		}
+	}
+	return updated, nil
+}
+
+// MarkAllRead marks every unread item as read and returns how many changed
+func (s *Service) MarkAllRead(ctx context.Context, userID string) (int, error) {
+	items, err := s.items(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+	var unread []string
+	for _, item := range items {
+		if !item.Read() {
+			unread = append(unread, item.ID)
+		}
+	}
+	return s.Update(ctx, userID, unread, ActionRead)
+}
+
+// Expire deletes expired items of every user and returns how many
+func (s *Service) Expire(ctx context.Context) (int, error) {
+	now := s.now()
+	var stale []string
+	err := s.kv.Scan(ctx, bucket, "", func(k string, value []byte) error {
+		var item Item
+		if err := json.Unmarshal(value, &item); err != nil {
+			return fmt.Errorf("decode inbox item %s: %w", k, err)
+		}
+		if s.expired(&item, now) {
+			stale = append(stale, k)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	for i, k := range stale {
+		if err := s.kv.Delete(ctx, bucket, k); err != nil {
+			return i, err
+		}
+	}
+	return len(stale), nil
+}
+
+// Run deletes expired items every interval until ctx is done
+func (s *Service) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			removed, err := s.Expire(ctx)
+			if err != nil {
+				logging.GetLogger().Error("failed to expire inbox items", zap.Error(err))
+				continue
+			}
+			if removed > 0 {
+				logging.GetLogger().Info("expired inbox items", zap.Int("removed", removed))
+			}
+		}
+	}
+}
+
+// items returns the user's unexpired items, newest first
+func (s *Service) items(ctx context.Context, userID string) ([]Item, error) {
+	now := s.now()
+	var items []Item
+	err := s.kv.Scan(ctx, bucket, userPrefix(userID), func(k string, value []byte) error {
+		var item Item
+		if err := json.Unmarshal(value, &item); err != nil {
+			return fmt.Errorf("decode inbox item %s: %w", k, err)
+		}
+		if !s.expired(&item, now) {
+			items = append(items, item)
+		}
+		return nil
+	})
+	return items, err
+}
+
+// find returns the user's item added with key, or nil if there is none
+func (s *Service) find(ctx context.Context, userID, key string) (*Item, error) {
+	var found *Item
+	err := s.kv.Scan(ctx, bucket, userPrefix(userID), func(k string, value []byte) error {
+		var item Item
+		if err := json.Unmarshal(value, &item); err != nil {
+			return fmt.Errorf("decode inbox item %s: %w", k, err)
+		}
+		if item.Key != key {
+			return nil
+		}
+		found = &item
+		return errFound
+	})
+	if err != nil && !errors.Is(err, errFound) {
+		return nil, err
+	}
+	return found, nil
+}
+
+// trim deletes the user's oldest items beyond maxItems
+func (s *Service) trim(ctx context.Context, userID string) error {
+	if s.maxItems <= 0 {
+		return nil
+	}
+	var excess []string
+	seen := 0
+	err := s.kv.Scan(ctx, bucket, userPrefix(userID), func(k string, _ []byte) error {
+		seen++
+		if seen > s.maxItems {
+			excess = append(excess, k)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for _, k := range excess {
+		if err := s.kv.Delete(ctx, bucket, k); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Service) expired(item *Item, now time.Time) bool {
+	if s.ttl > 0 && !now.Before(item.CreatedAt.Add(s.ttl)) {
+		return true
+	}
+	return s.readTTL > 0 && item.ReadAt != nil && !now.Before(item.ReadAt.Add(s.readTTL))
+}
+
+func matches(item *Item, filter string) bool {
+	switch filter {
+	case FilterUnread:
+		return !item.Read() && !item.Archived()
+	case FilterArchived:
+		return item.Archived()
+	default:
+		return !item.Archived()
+	}
+}
+
+// apply changes item and reports whether anything changed
+func apply(item *Item, action string, now time.Time) bool {
+	switch action {
+	case ActionRead:
+		if item.Read() {
+			return false
+		}
+		item.ReadAt = &now
+	case ActionUnread:
+		if !item.Read() {
+			return false
+		}
+		item.ReadAt = nil
+	case ActionArchive:
+		if item.Archived() {
+			return false
+		}
+		item.ArchivedAt = &now
+	case ActionUnarchive:
+		if !item.Archived() {
+			return false
+		}
+		item.ArchivedAt = nil
+	}
+	return true
+}
+
+func key(userID, id string) string {
+	return userPrefix(userID) + id
+}
+
+// userPrefix starts the keys of a user's items. The user ID is escaped so
+// that no user's prefix is the prefix of another user's keys.
+func userPrefix(userID string) string {
+	return url.PathEscape(userID) + "/"
+}
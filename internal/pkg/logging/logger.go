@@ -9,6 +9,7 @@ import (
 	"sync"
 
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 	"gopkg.in/natefinch/lumberjack.v2"
@@ -18,6 +19,7 @@ const (
 	// Context keys for tracking request information
 	RequestIDKey  = "x-request-id"
 	CorrelationID = "x-correlation-id"
+	CallerService = "x-caller-service"
 )
 
 var (
@@ -38,6 +40,8 @@ var (
 	}
 	// Standard metadata fields that will be included in all logs
 	standardFields []zap.Field
+	// level gates every sink and can be changed at runtime through SetLevel
+	level = zap.NewAtomicLevelAt(zapcore.DebugLevel)
 )
 
 // Logger wraps zap.Logger
@@ -50,10 +54,27 @@ type LogConfig struct {
 	Environment string
 	Version     string
 	ServiceName string
+	// MaskFields maps field names to a MaskStrategy; nil keeps the defaults
+	MaskFields   map[string]string
+	MaskHashSalt string
+	// Level is the minimum level logged; empty logs everything
+	Level string
 }
 
 // InitLogger configures the global logger instance with application metadata
 func InitLogger(cfg LogConfig) {
+	if cfg.MaskFields != nil {
+		if err := ConfigureMasking(cfg.MaskFields, cfg.MaskHashSalt); err != nil {
+			panic("invalid log masking configuration: " + err.Error())
+		}
+	} else {
+		hashSalt = cfg.MaskHashSalt
+	}
+	if cfg.Level != "" {
+		if err := SetLevel(cfg.Level); err != nil {
+			panic("invalid log level: " + err.Error())
+		}
+	}
 	// Set standard fields that will be included in all logs
 	standardFields = []zap.Field{
 		zap.String("service", cfg.ServiceName),
@@ -91,18 +112,18 @@ func InitLogger(cfg LogConfig) {
 	})
 	// Priority levels for routing logs
 	highPriority := zap.LevelEnablerFunc(func(lvl zapcore.Level) bool {
-		return lvl >= zapcore.ErrorLevel
+		return lvl >= zapcore.ErrorLevel && level.Enabled(lvl)
 	})
 	lowPriority := zap.LevelEnablerFunc(func(lvl zapcore.Level) bool {
-		return lvl < zapcore.ErrorLevel && lvl >= zapcore.InfoLevel
+		return lvl < zapcore.ErrorLevel && lvl >= zapcore.InfoLevel && level.Enabled(lvl)
 	})
 	// Multi-sink setup for different log levels
 	cores := []zapcore.Core{
-		// File output with rotation for all logs
+		// File output with rotation for all enabled levels
 		zapcore.NewCore(
 			zapcore.NewJSONEncoder(encoderConfig),
 			fileWriter,
-			zap.LevelEnablerFunc(func(lvl zapcore.Level) bool { return true }),
+			level,
 		),
 		// Stderr for errors
 		zapcore.NewCore(
@@ -129,6 +150,22 @@ func InitLogger(cfg LogConfig) {
 	instance = &Logger{zapLogger}
 }
 
+// SetLevel changes the minimum logged level of every logger, including
+// children created earlier
+func SetLevel(name string) error {
+	lvl, err := zapcore.ParseLevel(name)
+	if err != nil {
+		return err
+	}
+	level.SetLevel(lvl)
+	return nil
+}
+
+// Level returns the current minimum logged level
+func Level() string {
+	return level.Level().String()
+}
+
 // GetLogger returns the singleton logger instance, initializing if necessary
 func GetLogger() *Logger {
 	once.Do(func() {
@@ -145,6 +182,13 @@ func GetLogger() *Logger {
 	return instance
 }
 
+// Discard replaces the global logger with one that drops every entry, so
+// tests do not write log files into the package directory
+func Discard() {
+	once.Do(func() {})
+	instance = &Logger{zap.NewNop()}
+}
+
 // WithContext enhances logger with request context information
 func (l *Logger) WithContext(ctx context.Context) *Logger {
 	if ctx == nil {
@@ -154,6 +198,16 @@ func (l *Logger) WithContext(ctx context.Context) *Logger {
 		zap.String("request_id", getStringFromContext(ctx, RequestIDKey)),
 		zap.String("correlation_id", getStringFromContext(ctx, CorrelationID)),
 	}
+	if caller := getStringFromContext(ctx, CallerService); caller != "" {
+		fields = append(fields, zap.String("caller_service", caller))
+	}
+	// Link log lines to the active trace
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		fields = append(fields,
+			zap.String("trace_id", sc.TraceID().String()),
+			zap.String("span_id", sc.SpanID().String()),
+		)
+	}
 	// Add goroutine ID for debugging concurrent issues
 	var buf [64]byte
 	n := runtime.Stack(buf[:], false)
@@ -161,11 +215,21 @@ func (l *Logger) WithContext(ctx context.Context) *Logger {
 	return &Logger{l.Logger.With(fields...)}
 }
 
+// With returns a child logger that adds fields to every entry
+func (l *Logger) With(fields ...zap.Field) *Logger {
+	return &Logger{l.Logger.With(fields...)}
+}
+
 // Shutdown flushes any buffered log entries
 func (l *Logger) Shutdown() error {
 	return l.Sync()
 }
 
+// CallerFromContext returns the name of the service that originated the request
+func CallerFromContext(ctx context.Context) string {
+	return getStringFromContext(ctx, CallerService)
+}
+
 // Helper to extract string values from context
 func getStringFromContext(ctx context.Context, key string) string {
 	if val, ok := ctx.Value(key).(string); ok {
@@ -174,23 +238,48 @@ func getStringFromContext(ctx context.Context, key string) string {
 	return ""
 }
 
-// MaskSensitiveData recursively masks sensitive fields in any data structure
+// MaskSensitiveData returns a copy of data that is safe to log. Maps,
+// slices and protobuf messages are walked recursively; values of sensitive
+// fields are masked according to their configured strategy.
 func MaskSensitiveData(data interface{}) interface{} {
+	if m, ok := isProto(data); ok {
+		if !m.ProtoReflect().IsValid() {
+			return nil
+		}
+		return maskProto(m.ProtoReflect())
+	}
+
 	switch v := data.(type) {
 	case map[string]interface{}:
+		masked := make(map[string]interface{}, len(v))
 		for key, val := range v {
-			if _, ok := sensitiveFields[strings.ToLower(key)]; ok {
-				v[key] = "****"
-			} else {
-				v[key] = MaskSensitiveData(val)
+			strategy, ok := strategyFor(key)
+			switch {
+			case !ok:
+				masked[key] = MaskSensitiveData(val)
+			case strategy == MaskRedact:
+				masked[key] = redacted
+			default:
+				if str, isString := val.(string); isString {
+					masked[key] = applyMask(strategy, str)
+				} else {
+					masked[key] = redacted
+				}
 			}
 		}
-		return v
+		return masked
+	case map[string]string:
+		masked := make(map[string]string, len(v))
+		for key, val := range v {
+			masked[key] = Mask(key, val)
+		}
+		return masked
 	case []interface{}:
+		masked := make([]interface{}, len(v))
 		for i, val := range v {
-			v[i] = MaskSensitiveData(val)
+			masked[i] = MaskSensitiveData(val)
 		}
-		return v
+		return masked
 	default:
 		return data
 	}
@@ -0,0 +1,200 @@
+package logging
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// MaskStrategy controls how a sensitive value is rendered in logs
+type MaskStrategy string
+
+const (
+	// MaskRedact replaces the whole value
+	MaskRedact MaskStrategy = "redact"
+	// MaskPartial keeps the first and last characters, e.g. "+9****21"
+	MaskPartial MaskStrategy = "partial"
+	// MaskEmail keeps the first letter and the domain, e.g. "j***@example.com"
+	MaskEmail MaskStrategy = "email"
+	// MaskHash replaces the value with a stable digest so log lines about
+	// the same recipient can still be correlated
+	MaskHash MaskStrategy = "hash"
+)
+
+const redacted = "****"
+
+var (
+	// Fields masked by default in addition to sensitiveFields
+	defaultMaskRules = map[string]MaskStrategy{
+		"otp":           MaskRedact,
+		"to":            MaskEmail,
+		"email":         MaskEmail,
+		"emails":        MaskEmail,
+		"device_token":  MaskHash,
+		"device_tokens": MaskHash,
+		"token":         MaskHash,
+		"phone":         MaskPartial,
+		"phone_number":  MaskPartial,
+		"phones":        MaskPartial,
+	}
+	maskRules = defaultMaskRules
+	hashSalt  string
+)
+
+// ConfigureMasking replaces the field → strategy rules and the salt used by
+// MaskHash. It must be called before the logger is used concurrently.
+func ConfigureMasking(rules map[string]string, salt string) error {
+	parsed := make(map[string]MaskStrategy, len(rules))
+	for field, strategy := range rules {
+		s := MaskStrategy(strings.ToLower(strategy))
+		switch s {
+		case MaskRedact, MaskPartial, MaskEmail, MaskHash:
+		default:
+			return fmt.Errorf("unknown mask strategy %q for field %q", strategy, field)
+		}
+		parsed[strings.ToLower(field)] = s
+	}
+	maskRules = parsed
+	hashSalt = salt
+	return nil
+}
+
+// Mask renders value for logging according to the rule configured for field.
+// Values of fields without a rule are returned unchanged.
+func Mask(field, value string) string {
+	strategy, ok := strategyFor(field)
+	if !ok {
+		return value
+	}
+	return applyMask(strategy, value)
+}
+
+func strategyFor(field string) (MaskStrategy, bool) {
+	field = strings.ToLower(field)
+	if _, ok := sensitiveFields[field]; ok {
+		return MaskRedact, true
+	}
+	strategy, ok := maskRules[field]
+	return strategy, ok
+}
+
+func applyMask(strategy MaskStrategy, value string) string {
+	if value == "" {
+		return value
+	}
+
+	switch strategy {
+	case MaskEmail:
+		local, domain, ok := strings.Cut(value, "@")
+		if !ok || local == "" {
+			return applyMask(MaskPartial, value)
+		}
+		return local[:1] + "***@" + domain
+	case MaskPartial:
+		runes := []rune(value)
+		if len(runes) <= 4 {
+			return redacted
+		}
+		return string(runes[:2]) + redacted + string(runes[len(runes)-2:])
+	case MaskHash:
+		sum := sha256.Sum256([]byte(hashSalt + value))
+		return "sha256:" + hex.EncodeToString(sum[:8])
+	default:
+		return redacted
+	}
+}
+
+// maskProto converts a protobuf message into a loggable map, masking fields
+// annotated with [debug_redact = true] and fields with a configured rule
+func maskProto(m protoreflect.Message) map[string]interface{} {
+	out := make(map[string]interface{}, m.Descriptor().Fields().Len())
+
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		name := string(fd.Name())
+
+		if opts, ok := fd.Options().(*descriptorpb.FieldOptions); ok && opts.GetDebugRedact() {
+			out[name] = redacted
+			return true
+		}
+		strategy, masked := strategyFor(name)
+
+		switch {
+		case fd.IsList():
+			list := v.List()
+			items := make([]interface{}, list.Len())
+			for i := 0; i < list.Len(); i++ {
+				items[i] = maskProtoValue(fd, list.Get(i), strategy, masked)
+			}
+			out[name] = items
+		case fd.IsMap():
+			entries := make(map[string]interface{}, v.Map().Len())
+			v.Map().Range(func(k protoreflect.MapKey, mv protoreflect.Value) bool {
+				key := k.String()
+				// Map keys act as field names, e.g. PushRequest.data["phone"]
+				if keyStrategy, ok := strategyFor(key); ok && fd.MapValue().Kind() == protoreflect.StringKind {
+					entries[key] = applyMask(keyStrategy, mv.String())
+				} else {
+					entries[key] = maskProtoValue(fd.MapValue(), mv, strategy, masked)
+				}
+				return true
+			})
+			out[name] = entries
+		default:
+			out[name] = maskProtoValue(fd, v, strategy, masked)
+		}
+		return true
+	})
+
+	return out
+}
+
+func maskProtoValue(fd protoreflect.FieldDescriptor, v protoreflect.Value, strategy MaskStrategy, masked bool) interface{} {
+	switch fd.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		msg := v.Message().Interface()
+		// Unpack Any payloads so their fields are masked too
+		if anyMsg, ok := msg.(*anypb.Any); ok {
+			inner, err := anyMsg.UnmarshalNew()
+			if err != nil {
+				return map[string]interface{}{"@type": anyMsg.GetTypeUrl()}
+			}
+			msg = inner
+		}
+		if masked && strategy == MaskRedact {
+			return redacted
+		}
+		return maskProto(msg.ProtoReflect())
+	case protoreflect.StringKind:
+		if masked {
+			return applyMask(strategy, v.String())
+		}
+		return v.String()
+	case protoreflect.BytesKind:
+		if masked {
+			return redacted
+		}
+		return v.Bytes()
+	case protoreflect.EnumKind:
+		if ev := fd.Enum().Values().ByNumber(v.Enum()); ev != nil {
+			return string(ev.Name())
+		}
+		return int32(v.Enum())
+	default:
+		if masked {
+			return redacted
+		}
+		return v.Interface()
+	}
+}
+
+// isProto reports whether data is a protobuf message
+func isProto(data interface{}) (proto.Message, bool) {
+	m, ok := data.(proto.Message)
+	return m, ok && m != nil
+}
@@ -0,0 +1,164 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const namespace = "notification"
+
+// Send outcome labels
+const (
+	StatusSent    = "sent"
+	StatusFailed  = "failed"
+	StatusSkipped = "skipped"
+)
+
+var (
+	// Notification delivery
+	SendsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "sends_total",
+		Help:      "Notifications processed by channel, type and outcome.",
+	}, []string{"channel", "type", "status"})
+
+	NotificationsDispatchedTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "notifications_dispatched_total",
+		Help:      "SendNotification requests by type and the channel that delivered them (\"none\" when all failed, \"opted_out\" when blocked by preferences).",
+	}, []string{"type", "channel"})
+
+	NotificationsHeldTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "notifications_held_total",
+		Help:      "Notifications stored to be sent later, by type and reason.",
+	}, []string{"type", "reason"})
+
+	RealtimeStreamsActive = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "realtime_streams_active",
+		Help:      "SubscribeNotifications streams currently open on this instance.",
+	})
+
+	RealtimeSlowSubscribersTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "realtime_slow_subscribers_total",
+		Help:      "Streams disconnected for falling too far behind.",
+	})
+
+	WebhookDeliveriesTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "webhook_deliveries_total",
+		Help:      "Webhook delivery attempts by outcome (delivered, retrying or failed).",
+	}, []string{"status"})
+
+	WebhookSubscriptionsDisabledTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "webhook_subscriptions_disabled_total",
+		Help:      "Webhook subscriptions disabled after sustained delivery failures.",
+	})
+
+	EmailBounceReportsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "email_bounce_reports_total",
+		Help:      "Bounce and complaint reports processed by resulting email status.",
+	}, []string{"status"})
+
+	DeviceTokensPrunedTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "device_tokens_pruned_total",
+		Help:      "Device tokens removed after the push provider reported them as unregistered.",
+	})
+
+	SendDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "send_duration_seconds",
+		Help:      "End-to-end send latency including retries.",
+		Buckets:   []float64{.05, .1, .25, .5, 1, 2.5, 5, 10, 30},
+	}, []string{"channel", "type"})
+
+	SendRetriesTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "send_retries_total",
+		Help:      "Send attempts beyond the first.",
+	}, []string{"channel", "type"})
+
+	TemplateRenderErrorsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "template_render_errors_total",
+		Help:      "Failures parsing or executing notification templates.",
+	}, []string{"template"})
+
+	RateLimitedTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "rate_limited_total",
+		Help:      "Notifications rejected by a rate limit rule.",
+	}, []string{"rule"})
+
+	ProviderRequestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "provider_requests_total",
+		Help:      "Requests to external delivery providers by channel, provider and result.",
+	}, []string{"channel", "provider", "result"})
+
+	ThrottleWait = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "throttle_wait_seconds",
+		Help:      "Time sends spent queued behind a provider token bucket.",
+		Buckets:   []float64{.001, .01, .05, .1, .25, .5, 1, 2.5, 5, 10},
+	}, []string{"key"})
+
+	// SMTP connections
+	SMTPSessionsActive = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "smtp_sessions_active",
+		Help:      "SMTP sessions currently open.",
+	})
+
+	SMTPSessionsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "smtp_sessions_total",
+		Help:      "SMTP sessions by result.",
+	}, []string{"result"})
+
+	// Kafka consumer
+	KafkaConsumerLag = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "kafka_consumer_lag",
+		Help:      "Messages between the last consumed offset and the partition high watermark.",
+	}, []string{"topic", "partition"})
+
+	KafkaProcessingDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "kafka_message_processing_seconds",
+		Help:      "Time spent handling a Kafka message.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"topic", "status"})
+
+	// Runtime configuration
+	ConfigReloadsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "config_reloads_total",
+		Help:      "Configuration reload attempts by result.",
+	}, []string{"result"})
+
+	ConfigLastReloadTimestamp = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "config_last_reload_timestamp_seconds",
+		Help:      "Unix time of the last successfully applied configuration.",
+	})
+
+	// gRPC server
+	GRPCRequestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "grpc_requests_total",
+		Help:      "gRPC requests by method and status code.",
+	}, []string{"method", "code"})
+
+	GRPCRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "grpc_request_duration_seconds",
+		Help:      "gRPC request latency by method and status code.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "code"})
+)
@@ -0,0 +1,33 @@
+package metrics
+
+import (
+	"ride-sharing-notification/internal/pkg/throttle"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var throttleQueuedDesc = prometheus.NewDesc(
+	prometheus.BuildFQName(namespace, "", "throttle_queued"),
+	"Sends currently waiting for a provider token.",
+	[]string{"key"}, nil,
+)
+
+// throttleCollector reads queue depth from a throttler at scrape time
+type throttleCollector struct {
+	throttler *throttle.Throttler
+}
+
+// RegisterThrottler exposes the throttler's queue depth per bucket
+func RegisterThrottler(t *throttle.Throttler) {
+	prometheus.MustRegister(&throttleCollector{throttler: t})
+}
+
+func (c *throttleCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- throttleQueuedDesc
+}
+
+func (c *throttleCollector) Collect(ch chan<- prometheus.Metric) {
+	for key, stats := range c.throttler.Stats() {
+		ch <- prometheus.MustNewConstMetric(throttleQueuedDesc, prometheus.GaugeValue, float64(stats.Queued), key)
+	}
+}
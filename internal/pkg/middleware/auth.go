@@ -0,0 +1,79 @@
+package middleware
+
+import (
+	"context"
+	"strings"
+
+	"ride-sharing-notification/internal/pkg/auth"
+	"ride-sharing-notification/internal/pkg/errors"
+	"ride-sharing-notification/internal/pkg/logging"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// Services reachable without credentials so probes and tooling keep working
+var unauthenticatedServices = []string{
+	"/" + grpc_health_v1.Health_ServiceDesc.ServiceName + "/",
+	"/grpc.reflection.",
+}
+
+// AuthInterceptor returns a new unary server interceptor that authenticates
+// the calling service and enforces the per-method allowlist. A nil
+// authenticator disables the check.
+func AuthInterceptor(authenticator *auth.Authenticator) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := authenticate(ctx, authenticator, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamAuthInterceptor is the streaming counterpart of AuthInterceptor
+func StreamAuthInterceptor(authenticator *auth.Authenticator) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authenticate(ss.Context(), authenticator, info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+func authenticate(ctx context.Context, authenticator *auth.Authenticator, fullMethod string) (context.Context, error) {
+	if authenticator == nil || isUnauthenticated(fullMethod) {
+		// Still surface a client certificate identity for logging and limits
+		if id, ok := auth.PeerIdentity(ctx); ok {
+			ctx = auth.WithIdentity(ctx, id)
+			ctx = context.WithValue(ctx, logging.CallerService, id.Service)
+		}
+		return ctx, nil
+	}
+
+	ctx, appErr := authenticator.Authenticate(ctx, fullMethod)
+	if appErr != nil {
+		logging.GetLogger().WithContext(ctx).Warn("gRPC authentication failed",
+			zap.String("method", fullMethod),
+			zap.Error(appErr),
+		)
+		return ctx, errors.ToGRPCStatus(appErr)
+	}
+
+	// The verified identity supersedes any self-reported caller header
+	if id, ok := auth.IdentityFromContext(ctx); ok {
+		ctx = context.WithValue(ctx, logging.CallerService, id.Service)
+	}
+	return ctx, nil
+}
+
+func isUnauthenticated(fullMethod string) bool {
+	for _, prefix := range unauthenticatedServices {
+		if strings.HasPrefix(fullMethod, prefix) {
+			return true
+		}
+	}
+	return false
+}
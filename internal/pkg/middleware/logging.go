@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"ride-sharing-notification/internal/pkg/auth"
 	"ride-sharing-notification/internal/pkg/logging"
 
 	"go.uber.org/zap"
@@ -16,22 +17,7 @@ import (
 func LoggingInterceptor() grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
 		start := time.Now()
-
-		// Get or generate tracking IDs from metadata
-		md, _ := metadata.FromIncomingContext(ctx)
-		requestID := getFirstValue(md, logging.RequestIDKey)
-		if requestID == "" {
-			requestID = logging.GenerateID()
-		}
-
-		correlationID := getFirstValue(md, logging.CorrelationID)
-		if correlationID == "" {
-			correlationID = logging.GenerateID()
-		}
-
-		// Add IDs to context
-		ctx = context.WithValue(ctx, logging.RequestIDKey, requestID)
-		ctx = context.WithValue(ctx, logging.CorrelationID, correlationID)
+		ctx = withRequestIDs(ctx)
 
 		// Get logger with request context
 		logger := logging.GetLogger().WithContext(ctx)
@@ -71,6 +57,63 @@ func LoggingInterceptor() grpc.UnaryServerInterceptor {
 	}
 }
 
+// StreamLoggingInterceptor is the streaming counterpart of
+// LoggingInterceptor. Stream messages are not logged, only the start and
+// end of the stream.
+func StreamLoggingInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		ctx := withRequestIDs(ss.Context())
+		logger := logging.GetLogger().WithContext(ctx)
+
+		logger.Info("gRPC stream started", zap.String("method", info.FullMethod))
+
+		err := handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+
+		fields := []zap.Field{
+			zap.String("method", info.FullMethod),
+			zap.Duration("latency", time.Since(start)),
+		}
+		if err != nil {
+			st, _ := status.FromError(err)
+			fields = append(fields,
+				zap.String("grpc_code", st.Code().String()),
+				zap.String("error", st.Message()),
+			)
+			logger.Error("gRPC stream failed", fields...)
+		} else {
+			logger.Info("gRPC stream completed", fields...)
+		}
+
+		return err
+	}
+}
+
+// withRequestIDs adds the request and correlation IDs from metadata, or new
+// ones, and the calling service to ctx
+func withRequestIDs(ctx context.Context) context.Context {
+	md, _ := metadata.FromIncomingContext(ctx)
+	requestID := getFirstValue(md, logging.RequestIDKey)
+	if requestID == "" {
+		requestID = logging.GenerateID()
+	}
+
+	correlationID := getFirstValue(md, logging.CorrelationID)
+	if correlationID == "" {
+		correlationID = logging.GenerateID()
+	}
+
+	ctx = context.WithValue(ctx, logging.RequestIDKey, requestID)
+	ctx = context.WithValue(ctx, logging.CorrelationID, correlationID)
+	// A verified client certificate outranks the self-reported header
+	if id, ok := auth.PeerIdentity(ctx); ok {
+		ctx = context.WithValue(ctx, logging.CallerService, id.Service)
+	} else if caller := getFirstValue(md, logging.CallerService); caller != "" {
+		ctx = context.WithValue(ctx, logging.CallerService, caller)
+	}
+	return ctx
+}
+
 // getFirstValue helper to extract first value from metadata
 func getFirstValue(md metadata.MD, key string) string {
 	vals := md.Get(key)
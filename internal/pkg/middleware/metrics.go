@@ -0,0 +1,39 @@
+package middleware
+
+import (
+	"context"
+	"time"
+
+	"ride-sharing-notification/internal/pkg/metrics"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// MetricsInterceptor returns a new unary server interceptor that records
+// request counts and latency per method and status code
+func MetricsInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		observe(info.FullMethod, start, err)
+		return resp, err
+	}
+}
+
+// StreamMetricsInterceptor is the streaming counterpart of
+// MetricsInterceptor; the duration covers the whole stream
+func StreamMetricsInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, ss)
+		observe(info.FullMethod, start, err)
+		return err
+	}
+}
+
+func observe(method string, start time.Time, err error) {
+	code := status.Code(err).String()
+	metrics.GRPCRequestsTotal.WithLabelValues(method, code).Inc()
+	metrics.GRPCRequestDuration.WithLabelValues(method, code).Observe(time.Since(start).Seconds())
+}
@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+
+	"ride-sharing-notification/internal/pkg/errors"
+	"ride-sharing-notification/internal/pkg/logging"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+)
+
+// RecoveryInterceptor returns a new unary server interceptor that turns a
+// panicking handler into a codes.Internal error instead of crashing the process
+func RecoveryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = recoverPanic(ctx, info.FullMethod, r)
+			}
+		}()
+		return handler(ctx, req)
+	}
+}
+
+// StreamRecoveryInterceptor is the streaming counterpart of RecoveryInterceptor
+func StreamRecoveryInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = recoverPanic(ss.Context(), info.FullMethod, r)
+			}
+		}()
+		return handler(srv, ss)
+	}
+}
+
+func recoverPanic(ctx context.Context, method string, r interface{}) error {
+	logging.GetLogger().WithContext(ctx).Error("recovered from panic in gRPC handler",
+		zap.String("method", method),
+		zap.Any("panic", r),
+		zap.ByteString("stack", debug.Stack()),
+	)
+	return errors.ToGRPCStatus(errors.NewInternalError(fmt.Errorf("panic: %v", r)))
+}
@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+// TimeoutInterceptor returns a new unary server interceptor that bounds
+// requests arriving without a deadline. perMethod overrides the default and
+// is keyed by full method or bare RPC name; a zero timeout disables the bound.
+func TimeoutInterceptor(defaultTimeout time.Duration, perMethod map[string]time.Duration) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, cancel := withMethodTimeout(ctx, info.FullMethod, defaultTimeout, perMethod)
+		defer cancel()
+		return handler(ctx, req)
+	}
+}
+
+// StreamTimeoutInterceptor is the streaming counterpart of TimeoutInterceptor.
+// Long-lived streams should be given a zero per-method timeout.
+func StreamTimeoutInterceptor(defaultTimeout time.Duration, perMethod map[string]time.Duration) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, cancel := withMethodTimeout(ss.Context(), info.FullMethod, defaultTimeout, perMethod)
+		defer cancel()
+		return handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+func withMethodTimeout(ctx context.Context, method string, defaultTimeout time.Duration, perMethod map[string]time.Duration) (context.Context, context.CancelFunc) {
+	// Respect deadlines set by the caller
+	if _, ok := ctx.Deadline(); ok {
+		return ctx, func() {}
+	}
+
+	timeout := defaultTimeout
+	if t, ok := perMethod[method]; ok {
+		timeout = t
+	} else if t, ok := perMethod[method[strings.LastIndex(method, "/")+1:]]; ok {
+		timeout = t
+	}
+	if timeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// contextStream overrides the context of a wrapped server stream
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
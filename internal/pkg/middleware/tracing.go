@@ -0,0 +1,85 @@
+package middleware
+
+import (
+	"context"
+
+	"ride-sharing-notification/internal/pkg/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// TracingInterceptor returns a new unary server interceptor that continues
+// the caller's W3C trace (if any) and opens a server span per request
+func TracingInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, span := startServerSpan(ctx, info.FullMethod)
+		defer span.End()
+
+		resp, err := handler(ctx, req)
+		endServerSpan(span, err)
+		return resp, err
+	}
+}
+
+// StreamTracingInterceptor is the streaming counterpart of
+// TracingInterceptor; the span covers the whole stream
+func StreamTracingInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, span := startServerSpan(ss.Context(), info.FullMethod)
+		defer span.End()
+
+		err := handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+		endServerSpan(span, err)
+		return err
+	}
+}
+
+func startServerSpan(ctx context.Context, method string) (context.Context, trace.Span) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	ctx = tracing.Extract(ctx, metadataCarrier(md))
+
+	return tracing.Start(ctx, method,
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(
+			semconv.RPCSystemGRPC,
+			attribute.String("rpc.method", method),
+		),
+	)
+}
+
+func endServerSpan(span trace.Span, err error) {
+	st, _ := status.FromError(err)
+	span.SetAttributes(semconv.RPCGRPCStatusCodeKey.Int(int(st.Code())))
+	if err != nil {
+		span.SetStatus(otelcodes.Error, st.Message())
+	}
+}
+
+// metadataCarrier adapts gRPC metadata to the OpenTelemetry propagator API
+type metadataCarrier metadata.MD
+
+func (c metadataCarrier) Get(key string) string {
+	vals := metadata.MD(c).Get(key)
+	if len(vals) > 0 {
+		return vals[0]
+	}
+	return ""
+}
+
+func (c metadataCarrier) Set(key, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+func (c metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+	return keys
+}
@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"context"
+	stderrors "errors"
+
+	"ride-sharing-notification/internal/pkg/errors"
+
+	"google.golang.org/grpc"
+)
+
+// validator is implemented by request messages that can check their own fields
+type validator interface {
+	Validate() error
+}
+
+// ValidationInterceptor returns a new unary server interceptor that rejects
+// requests whose Validate method fails before they reach the handler
+func ValidationInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := validate(req); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamValidationInterceptor validates every message received on a stream
+func StreamValidationInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &validatingStream{ServerStream: ss})
+	}
+}
+
+type validatingStream struct {
+	grpc.ServerStream
+}
+
+func (s *validatingStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	return validate(m)
+}
+
+func validate(req interface{}) error {
+	v, ok := req.(validator)
+	if !ok {
+		return nil
+	}
+
+	err := v.Validate()
+	if err == nil {
+		return nil
+	}
+
+	var appErr *errors.AppError
+	if stderrors.As(err, &appErr) {
+		return errors.ToGRPCStatus(appErr)
+	}
+	return errors.ToGRPCStatus(errors.NewValidationError(err.Error(), nil))
+}
@@ -0,0 +1,308 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"ride-sharing-notification/config"
+	"ride-sharing-notification/internal/pkg/logging"
+	"ride-sharing-notification/internal/pkg/metrics"
+	"ride-sharing-notification/internal/pkg/preferences"
+	"ride-sharing-notification/internal/pkg/push"
+	"ride-sharing-notification/internal/pkg/storage"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// DigestType is the notification type batched notifications are sent as
+const DigestType = "DIGEST"
+
+// Digest windows
+const (
+	WindowHourly = "hourly"
+	WindowDaily  = "daily"
+)
+
+const digestBucket = "digests"
+
+// digestSettings is the configured batching policy
+type digestSettings struct {
+	enabled bool
+	// windows maps upper-case notification types to their window
+	windows map[string]string
+	// dailyAt is the local send time of daily digests in minutes
+	dailyAt  int
+	maxItems int
+}
+
+func digestSettingsFromConfig(cfg *config.Config) *digestSettings {
+	s := &digestSettings{
+		enabled:  cfg.Digest.Enabled,
+		windows:  make(map[string]string, len(cfg.Digest.Types)),
+		maxItems: cfg.Digest.MaxItems,
+	}
+	for notificationType, window := range cfg.Digest.Types {
+		s.windows[strings.ToUpper(notificationType)] = window
+	}
+	// Values are validated while loading the configuration
+	if t, err := time.Parse("15:04", cfg.Digest.DailyAt); err == nil {
+		s.dailyAt = t.Hour()*60 + t.Minute()
+	}
+	return s
+}
+
+// DigestItem is one batched notification. It is rendered when it is added,
+// so a digest lists each item the way its own push would have read.
+type DigestItem struct {
+	ID    string    `json:"id"`
+	Type  string    `json:"type"`
+	Title string    `json:"title"`
+	Body  string    `json:"body"`
+	At    time.Time `json:"at"`
+}
+
+// digest collects one recipient's items for one window. It is sent by a
+// scheduled job created together with it, so pending digests survive
+// restarts and are released with the same lease as other held notifications.
+type digest struct {
+	Recipient Recipient    `json:"recipient"`
+	Channels  []string     `json:"channels,omitempty"`
+	Window    string       `json:"window"`
+	DueAt     time.Time    `json:"due_at"`
+	Name      string       `json:"name,omitempty"`
+	Items     []DigestItem `json:"items"`
+	// More counts items beyond the configured maximum
+	More int `json:"more,omitempty"`
+}
+
+// batch adds req to the recipient's pending digest when its type is
+// batched. It reports false when req should be sent on its own.
+func (d *Dispatcher) batch(ctx context.Context, req *Request, prefs *preferences.Preferences) (*Outcome, bool, error) {
+	s := d.digests.Load()
+	window, ok := s.windows[strings.ToUpper(req.Type)]
+	recipient := recipientKey(req.Recipient)
+	if !s.enabled || req.Urgent || !ok || recipient == "" {
+		return nil, false, nil
+	}
+	title, body, err := push.Render(req.Type, req.Data)
+	if err != nil {
+		// Sent on its own so the template error is reported as usual
+		return nil, false, nil
+	}
+
+	now := d.now()
+	dueAt := windowEnd(now.In(d.location(req, prefs)), window, s.dailyAt).UTC()
+	key := recipient + "/" + window + "/" + dueAt.Format(dueKeyLayout)
+	item := DigestItem{
+		ID:    uuid.New().String(),
+		Type:  req.Type,
+		Title: title,
+		Body:  body,
+		At:    now.UTC(),
+	}
+
+	created := false
+	err = d.scheduler.kv.Update(ctx, digestBucket, key, func(current []byte) ([]byte, error) {
+		dg := digest{Recipient: req.Recipient, Channels: req.Channels, Window: window, DueAt: dueAt}
+		if current != nil {
+			if err := json.Unmarshal(current, &dg); err != nil {
+				return nil, fmt.Errorf("decode digest %s: %w", key, err)
+			}
+		}
+		created = current == nil
+		if name, ok := req.Data["name"].(string); ok && name != "" {
+			dg.Name = name
+		}
+		if len(dg.Items) < s.maxItems {
+			dg.Items = append(dg.Items, item)
+		} else {
+			dg.More++
+		}
+		return json.Marshal(&dg)
+	})
+	if err != nil {
+		return nil, true, fmt.Errorf("add to digest: %w", err)
+	}
+	if created {
+		if err := d.scheduleDigest(ctx, key, req.Recipient, dueAt); err != nil {
+			// Without a job the digest would never be sent
+			if _, rollbackErr := d.removeDigestItems(ctx, key, []string{item.ID}, 0); rollbackErr != nil {
+				err = errors.Join(err, rollbackErr)
+			}
+			return nil, true, err
+		}
+	}
+
+	metrics.NotificationsHeldTotal.WithLabelValues(req.Type, ReasonDigest).Inc()
+	logging.GetLogger().WithContext(ctx).Info("added notification to digest",
+		zap.String("type", req.Type),
+		zap.String("user_id", req.Recipient.UserID),
+		zap.String("window", window),
+		zap.Time("due_at", dueAt),
+	)
+	return &Outcome{HeldUntil: dueAt, HeldReason: ReasonDigest}, true, nil
+}
+
+func (d *Dispatcher) scheduleDigest(ctx context.Context, key string, recipient Recipient, dueAt time.Time) error {
+	_, err := d.scheduler.Add(ctx, Request{
+		Recipient: recipient,
+		Type:      DigestType,
+		Data:      map[string]interface{}{"digest": key},
+	}, dueAt, ReasonDigest)
+	return err
+}
+
+// sendDigest sends the digest a released job points at. Items the user
+// has since opted out of are dropped, and the digest waits while any of
+// its items would be held for quiet hours.
+func (d *Dispatcher) sendDigest(ctx context.Context, job *Job) (*Outcome, error) {
+	key, _ := job.Request.Data["digest"].(string)
+	var dg digest
+	if err := storage.GetJSON(ctx, d.scheduler.kv, digestBucket, key, &dg); err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			// Already sent by an earlier release
+			return &Outcome{}, nil
+		}
+		return nil, err
+	}
+
+	var prefs *preferences.Preferences
+	if dg.Recipient.UserID != "" {
+		var err error
+		if prefs, err = d.preferences.Get(ctx, dg.Recipient.UserID); err != nil {
+			return nil, err
+		}
+	}
+	ids := make([]string, 0, len(dg.Items))
+	items := make([]DigestItem, 0, len(dg.Items))
+	for _, item := range dg.Items {
+		ids = append(ids, item.ID)
+		if prefs == nil || prefs.Allows(item.Type) {
+			items = append(items, item)
+		}
+	}
+	if len(items) == 0 {
+		if _, err := d.removeDigestItems(ctx, key, ids, dg.More); err != nil {
+			return nil, err
+		}
+		metrics.NotificationsDispatchedTotal.WithLabelValues(DigestType, "opted_out").Inc()
+		return &Outcome{OptedOut: true}, nil
+	}
+	for _, item := range items {
+		if dueAt, held := d.holdUntil(&Request{Recipient: dg.Recipient, Type: item.Type}, prefs); held {
+			return d.hold(ctx, &job.Request, job, dueAt, ReasonQuietHours)
+		}
+	}
+
+	outcome, err := d.deliver(ctx, &Request{
+		Recipient: dg.Recipient,
+		Type:      DigestType,
+		Data:      digestData(&dg, items),
+		Channels:  dg.Channels,
+	}, prefs)
+	if err != nil {
+		return outcome, err
+	}
+
+	remaining, err := d.removeDigestItems(context.WithoutCancel(ctx), key, ids, dg.More)
+	if err != nil {
+		return outcome, err
+	}
+	if remaining {
+		// Items added while this digest was being sent go out right away
+		if err := d.scheduleDigest(context.WithoutCancel(ctx), key, dg.Recipient, d.now()); err != nil {
+			return outcome, err
+		}
+	}
+	return outcome, nil
+}
+
+// removeDigestItems deletes sent items from a digest, and the digest itself
+// once nothing is left. It reports whether items remain.
+func (d *Dispatcher) removeDigestItems(ctx context.Context, key string, ids []string, more int) (bool, error) {
+	remaining := false
+	err := d.scheduler.kv.Update(ctx, digestBucket, key, func(current []byte) ([]byte, error) {
+		if current == nil {
+			return nil, nil
+		}
+		var dg digest
+		if err := json.Unmarshal(current, &dg); err != nil {
+			return nil, fmt.Errorf("decode digest %s: %w", key, err)
+		}
+		kept := dg.Items[:0]
+		for _, item := range dg.Items {
+			if !slices.Contains(ids, item.ID) {
+				kept = append(kept, item)
+			}
+		}
+		dg.Items = kept
+		dg.More = max(dg.More-more, 0)
+		if len(dg.Items) == 0 && dg.More == 0 {
+			return nil, nil
+		}
+		remaining = true
+		return json.Marshal(&dg)
+	})
+	return remaining, err
+}
+
+// digestData is the template data of a digest
+func digestData(dg *digest, items []DigestItem) map[string]interface{} {
+	list := make([]map[string]interface{}, 0, len(items))
+	titles := make([]string, 0, len(items))
+	for _, item := range items {
+		list = append(list, map[string]interface{}{
+			"type":  item.Type,
+			"title": item.Title,
+			"body":  item.Body,
+			"at":    item.At,
+		})
+		titles = append(titles, item.Title)
+	}
+	data := map[string]interface{}{
+		"items":   list,
+		"count":   len(items) + dg.More,
+		"summary": strings.Join(titles, "; "),
+		"window":  dg.Window,
+	}
+	if dg.More > 0 {
+		data["more"] = dg.More
+	}
+	if dg.Name != "" {
+		data["name"] = dg.Name
+	}
+	return data
+}
+
+// windowEnd returns when the window containing now closes, in now's
+// location: the next full hour, or the next daily send time
+func windowEnd(now time.Time, window string, dailyAt int) time.Time {
+	if window == WindowHourly {
+		return time.Date(now.Year(), now.Month(), now.Day(), now.Hour()+1, 0, 0, 0, now.Location())
+	}
+	end := time.Date(now.Year(), now.Month(), now.Day(), 0, dailyAt, 0, 0, now.Location())
+	if !end.After(now) {
+		end = end.AddDate(0, 0, 1)
+	}
+	return end
+}
+
+// recipientKey identifies whose digest a notification belongs to. Requests
+// without any identity cannot be batched.
+func recipientKey(r Recipient) string {
+	switch {
+	case r.UserID != "":
+		return "user:" + r.UserID
+	case r.Email != "":
+		return "email:" + strings.ToLower(r.Email)
+	case r.Phone != "":
+		return "phone:" + r.Phone
+	}
+	return ""
+}
@@ -0,0 +1,569 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/textproto"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"ride-sharing-notification/config"
+	"ride-sharing-notification/internal/pkg/devices"
+	"ride-sharing-notification/internal/pkg/email"
+	"ride-sharing-notification/internal/pkg/inbox"
+	"ride-sharing-notification/internal/pkg/logging"
+	"ride-sharing-notification/internal/pkg/metrics"
+	"ride-sharing-notification/internal/pkg/preferences"
+	"ride-sharing-notification/internal/pkg/push"
+	"ride-sharing-notification/internal/pkg/quiethours"
+	"ride-sharing-notification/internal/pkg/ratelimit"
+	"ride-sharing-notification/internal/pkg/realtime"
+	"ride-sharing-notification/internal/pkg/sms"
+	"ride-sharing-notification/internal/pkg/suppression"
+	"ride-sharing-notification/internal/pkg/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// Channels a notification can be delivered through
+const (
+	ChannelEmail = "email"
+	ChannelSMS   = "sms"
+	ChannelPush  = "push"
+	// ChannelInApp streams the notification to the user's open apps
+	ChannelInApp = "in_app"
+)
+
+// Attempt outcomes. Skipped and failed channels are fallen back from;
+// unavailable (a temporary failure) and rate-limited channels are not.
+const (
+	StatusDelivered = "delivered"
+	StatusSkipped   = "skipped"
+	// StatusFailed means the channel can never deliver this notification
+	StatusFailed = "failed"
+	// StatusUnavailable means the channel failed but may deliver later
+	StatusUnavailable = "unavailable"
+	StatusRateLimited = "rate_limited"
+)
+
+// Recipient holds whatever contact points the caller knows for a user
+type Recipient struct {
+	UserID       string
+	Email        string
+	Phone        string
+	DeviceTokens []string
+}
+
+// Request is a notification to deliver through the first channel that works
+type Request struct {
+	// ID identifies the notification across retries, so a redelivered
+	// request adds its inbox item only once; empty skips that check
+	ID        string
+	Recipient Recipient
+	Type      string
+	Data      map[string]interface{}
+	// Channels in order of preference; empty uses the configured default
+	Channels []string
+	// TimeZone and QuietHours override the recipient's quiet hours settings
+	TimeZone   string
+	QuietHours *quiethours.Window
+	// Urgent sends the notification right away, skipping digests and
+	// quiet hours
+	Urgent bool
+}
+
+// Attempt records what happened on one channel
+type Attempt struct {
+	Channel   string
+	Status    string
+	MessageID string
+	Reason    string
+	// RetryAfter is how long a rate-limited channel stays closed
+	RetryAfter time.Duration
+}
+
+// Outcome reports every channel tried and which one delivered, if any
+type Outcome struct {
+	Channel   string
+	MessageID string
+	Attempts  []Attempt
+	// OptedOut is set when the user's preferences block the notification
+	OptedOut bool
+	// HeldUntil is set when the notification was stored to be sent later;
+	// HeldReason says why and ScheduleID identifies the job, if any
+	HeldUntil  time.Time
+	HeldReason string
+	ScheduleID string
+}
+
+// Delivered reports whether any channel delivered the notification
+func (o *Outcome) Delivered() bool {
+	return o.Channel != ""
+}
+
+// Held reports whether the notification was scheduled instead of sent
+func (o *Outcome) Held() bool {
+	return !o.HeldUntil.IsZero()
+}
+
+var (
+	// ErrUnknownChannel is returned for channel names the dispatcher does
+	// not know
+	ErrUnknownChannel = errors.New("unknown channel")
+	// ErrUnavailable is returned when a channel failed temporarily, so the
+	// notification should be sent again later
+	ErrUnavailable = errors.New("channel temporarily unavailable")
+)
+
+// Dispatcher sends a notification through the caller's preferred channels,
+// falling back to the next one when a channel fails permanently
+type Dispatcher struct {
+	email       *email.Service
+	sms         *sms.Service
+	push        *push.Service
+	preferences *preferences.Service
+	devices     *devices.Registry
+	scheduler   *Scheduler
+	realtime    *realtime.Hub
+	inbox       *inbox.Service
+	limiter     *ratelimit.Limiter
+	now         func() time.Time
+
+	defaultChannels atomic.Pointer[[]string]
+	quiet           atomic.Pointer[quietSettings]
+	digests         atomic.Pointer[digestSettings]
+}
+
+func NewDispatcher(emailSvc *email.Service, smsSvc *sms.Service, pushSvc *push.Service, preferencesSvc *preferences.Service, registry *devices.Registry, scheduler *Scheduler, hub *realtime.Hub, inboxSvc *inbox.Service, limiter *ratelimit.Limiter) *Dispatcher {
+	d := &Dispatcher{
+		email:       emailSvc,
+		sms:         smsSvc,
+		push:        pushSvc,
+		preferences: preferencesSvc,
+		devices:     registry,
+		scheduler:   scheduler,
+		realtime:    hub,
+		inbox:       inboxSvc,
+		limiter:     limiter,
+		now:         time.Now,
+	}
+	channels := []string{ChannelPush, ChannelSMS, ChannelEmail}
+	d.defaultChannels.Store(&channels)
+	d.quiet.Store(&quietSettings{})
+	d.digests.Store(&digestSettings{})
+	return d
+}
+
+// ApplyConfig updates the default channel order, quiet hours and digests
+func (d *Dispatcher) ApplyConfig(cfg *config.Config) {
+	d.quiet.Store(quietSettingsFromConfig(cfg))
+	d.digests.Store(digestSettingsFromConfig(cfg))
+	if len(cfg.Notify.DefaultChannels) == 0 {
+		return
+	}
+	channels := append([]string(nil), cfg.Notify.DefaultChannels...)
+	d.defaultChannels.Store(&channels)
+}
+
+// ValidateChannels checks that every name is a known channel
+func ValidateChannels(channels []string) error {
+	for _, channel := range channels {
+		switch channel {
+		case ChannelEmail, ChannelSMS, ChannelPush, ChannelInApp:
+		default:
+			return fmt.Errorf("%w: %q", ErrUnknownChannel, channel)
+		}
+	}
+	return nil
+}
+
+// Send tries each channel in order until one delivers, moving on only when
+// a channel is skipped or fails permanently. A rate-limited channel ends
+// the attempt undelivered. For known users the stored preferences decide
+// whether the notification may be sent and fill in missing contact points
+// and channel order. Types configured for digests are batched per
+// recipient and sent together when the window closes, and non-urgent
+// notifications during the recipient's quiet hours are held and sent when
+// they end.
+//
+// The outcome lists every attempt. An error wrapping ErrUnavailable is
+// returned with it when a channel failed temporarily; other errors mean
+// loading preferences or holding failed, or ctx ended.
+func (d *Dispatcher) Send(ctx context.Context, req *Request) (*Outcome, error) {
+	return d.send(ctx, req, nil)
+}
+
+// send dispatches req; job is the scheduled job it is released from, if any
+func (d *Dispatcher) send(ctx context.Context, req *Request, job *Job) (*Outcome, error) {
+	var prefs *preferences.Preferences
+	if req.Recipient.UserID != "" {
+		var err error
+		if prefs, err = d.preferences.Get(ctx, req.Recipient.UserID); err != nil {
+			return nil, err
+		}
+		if !prefs.Allows(req.Type) {
+			metrics.NotificationsDispatchedTotal.WithLabelValues(req.Type, "opted_out").Inc()
+			return &Outcome{OptedOut: true}, nil
+		}
+	}
+	// Held before contact points are filled in so the release uses the
+	// user's details as they are then
+	if outcome, batched, err := d.batch(ctx, req, prefs); batched || err != nil {
+		return outcome, err
+	}
+	if dueAt, held := d.holdUntil(req, prefs); held {
+		return d.hold(ctx, req, job, dueAt, ReasonQuietHours)
+	}
+	return d.deliver(ctx, req, prefs)
+}
+
+// deliver fills in the recipient's contact points and tries each channel
+func (d *Dispatcher) deliver(ctx context.Context, req *Request, prefs *preferences.Preferences) (*Outcome, error) {
+	channels := req.Channels
+	if prefs != nil {
+		var err error
+		req.Recipient = withContactPoints(req.Recipient, prefs)
+		if len(req.Recipient.DeviceTokens) == 0 {
+			if req.Recipient.DeviceTokens, err = d.devices.Tokens(ctx, req.Recipient.UserID); err != nil {
+				return nil, err
+			}
+		}
+		if len(channels) == 0 {
+			channels = prefs.Channels
+		}
+	}
+	if len(channels) == 0 {
+		channels = *d.defaultChannels.Load()
+	}
+	if err := ValidateChannels(channels); err != nil {
+		return nil, err
+	}
+
+	ctx, span := tracing.Start(ctx, "notification.dispatch",
+		trace.WithAttributes(
+			attribute.String("notification.type", req.Type),
+			attribute.StringSlice("notification.channels", channels),
+		),
+	)
+	defer span.End()
+
+	logger := logging.GetLogger().WithContext(ctx).With(
+		zap.String("type", req.Type),
+		zap.String("user_id", req.Recipient.UserID),
+	)
+
+	// The inbox keeps in-app notifications whichever channel delivers
+	var inboxID string
+	if slices.Contains(channels, ChannelInApp) {
+		inboxID = d.addToInbox(ctx, req)
+	}
+
+	outcome := &Outcome{}
+	for _, channel := range channels {
+		attempt := d.sendVia(ctx, channel, req, inboxID)
+		outcome.Attempts = append(outcome.Attempts, attempt)
+
+		if attempt.Status == StatusDelivered {
+			outcome.Channel = channel
+			outcome.MessageID = attempt.MessageID
+			break
+		}
+		if err := ctx.Err(); err != nil {
+			tracing.RecordError(span, err)
+			return outcome, err
+		}
+		if attempt.Status == StatusUnavailable {
+			err := fmt.Errorf("%w: %s: %s", ErrUnavailable, channel, attempt.Reason)
+			tracing.RecordError(span, err)
+			metrics.NotificationsDispatchedTotal.WithLabelValues(req.Type, "none").Inc()
+			return outcome, err
+		}
+		if attempt.Status == StatusRateLimited {
+			logger.Info("channel rate limited, not falling back",
+				zap.String("channel", channel),
+				zap.String("reason", attempt.Reason),
+			)
+			break
+		}
+		logger.Info("channel did not deliver, falling back",
+			zap.String("channel", channel),
+			zap.String("status", attempt.Status),
+			zap.String("reason", attempt.Reason),
+		)
+	}
+
+	delivered := outcome.Channel
+	if delivered == "" {
+		delivered = "none"
+	}
+	span.SetAttributes(attribute.String("notification.delivered_channel", delivered))
+	metrics.NotificationsDispatchedTotal.WithLabelValues(req.Type, delivered).Inc()
+	return outcome, nil
+}
+
+func (d *Dispatcher) sendVia(ctx context.Context, channel string, req *Request, inboxID string) Attempt {
+	switch channel {
+	case ChannelEmail:
+		return d.sendEmail(ctx, req)
+	case ChannelSMS:
+		return d.sendSMS(ctx, req)
+	case ChannelInApp:
+		return d.sendInApp(ctx, req, inboxID)
+	default:
+		return d.sendPush(ctx, req)
+	}
+}
+
+func (d *Dispatcher) sendEmail(ctx context.Context, req *Request) Attempt {
+	attempt := Attempt{Channel: ChannelEmail}
+	switch {
+	case req.Recipient.Email == "":
+		return skipped(attempt, "recipient has no email address")
+	case !d.email.Enabled():
+		return skipped(attempt, email.ErrDisabled.Error())
+	}
+	if _, ok := email.EmailTemplates[req.Type]; !ok {
+		return skipped(attempt, "no email template for "+req.Type)
+	}
+	if limited := d.rateLimited(ctx, ChannelEmail, req.Recipient.Email, req.Type); limited != nil {
+		return *limited
+	}
+
+	if _, err := d.email.VerifyEmail(ctx, &email.EmailPayload{
+		To:         req.Recipient.Email,
+		EMAIL_TYPE: req.Type,
+		Data:       req.Data,
+	}); err != nil {
+		return failed(attempt, err)
+	}
+	attempt.Status = StatusDelivered
+	return attempt
+}
+
+func (d *Dispatcher) sendSMS(ctx context.Context, req *Request) Attempt {
+	attempt := Attempt{Channel: ChannelSMS}
+	switch {
+	case req.Recipient.Phone == "":
+		return skipped(attempt, "recipient has no phone number")
+	case !d.sms.Enabled():
+		return skipped(attempt, sms.ErrDisabled.Error())
+	}
+	if _, ok := sms.SMSTemplates[req.Type]; !ok {
+		return skipped(attempt, "no sms template for "+req.Type)
+	}
+	to, err := sms.NormalizeE164(req.Recipient.Phone)
+	if err != nil {
+		return failed(attempt, err)
+	}
+	if limited := d.rateLimited(ctx, ChannelSMS, to, req.Type); limited != nil {
+		return *limited
+	}
+
+	result, err := d.sms.Send(ctx, &sms.Payload{
+		To:   to,
+		Type: req.Type,
+		Data: req.Data,
+	})
+	if err != nil {
+		return failed(attempt, err)
+	}
+	attempt.Status = StatusDelivered
+	attempt.MessageID = result.MessageID
+	return attempt
+}
+
+func (d *Dispatcher) sendPush(ctx context.Context, req *Request) Attempt {
+	attempt := Attempt{Channel: ChannelPush}
+	switch {
+	case len(req.Recipient.DeviceTokens) == 0:
+		return skipped(attempt, "recipient has no registered devices")
+	case !d.push.Enabled():
+		return skipped(attempt, push.ErrDisabled.Error())
+	}
+	if _, ok := push.PushTemplates[req.Type]; !ok {
+		return skipped(attempt, "no push template for "+req.Type)
+	}
+	// Push is limited per user since a user may have several devices
+	key := req.Recipient.UserID
+	if key == "" {
+		key = req.Recipient.DeviceTokens[0]
+	}
+	if limited := d.rateLimited(ctx, ChannelPush, key, req.Type); limited != nil {
+		return *limited
+	}
+
+	result, err := d.push.Send(ctx, &push.Payload{
+		Tokens: req.Recipient.DeviceTokens,
+		Type:   req.Type,
+		Data:   req.Data,
+	})
+	if err != nil {
+		return failed(attempt, err)
+	}
+	ids := make([]string, 0, len(result.Delivered))
+	for _, delivery := range result.Delivered {
+		ids = append(ids, delivery.MessageID)
+	}
+	attempt.Status = StatusDelivered
+	attempt.MessageID = strings.Join(ids, ",")
+	return attempt
+}
+
+// sendInApp publishes to the user's open app streams. The notification is
+// kept for replay either way, but only counts as delivered when an app is
+// connected, so the next channel is tried otherwise.
+func (d *Dispatcher) sendInApp(ctx context.Context, req *Request, inboxID string) Attempt {
+	attempt := Attempt{Channel: ChannelInApp}
+	title, body, data, reason, err := inAppContent(req)
+	if reason != "" {
+		return skipped(attempt, reason)
+	}
+	if err != nil {
+		return failed(attempt, err)
+	}
+
+	event, sent, err := d.realtime.Publish(ctx, realtime.Event{
+		UserID:  req.Recipient.UserID,
+		Type:    req.Type,
+		Title:   title,
+		Body:    body,
+		Data:    data,
+		InboxID: inboxID,
+	})
+	if err != nil {
+		return failed(attempt, err)
+	}
+	attempt.MessageID = event.Cursor()
+	if sent == 0 {
+		return skipped(attempt, "user has no app connected")
+	}
+	attempt.Status = StatusDelivered
+	return attempt
+}
+
+// addToInbox stores req in the user's inbox and returns the item ID. A
+// failure only costs the inbox copy, so it is logged and "" returned.
+func (d *Dispatcher) addToInbox(ctx context.Context, req *Request) string {
+	title, body, data, reason, err := inAppContent(req)
+	if reason != "" {
+		return ""
+	}
+	if err == nil {
+		var item *inbox.Item
+		item, err = d.inbox.Add(ctx, inbox.Item{
+			Key:    req.ID,
+			UserID: req.Recipient.UserID,
+			Type:   req.Type,
+			Title:  title,
+			Body:   body,
+			Data:   data,
+		})
+		if err == nil {
+			return item.ID
+		}
+	}
+	logging.GetLogger().WithContext(ctx).Warn("failed to add notification to inbox",
+		zap.String("type", req.Type),
+		zap.String("user_id", req.Recipient.UserID),
+		zap.Error(err),
+	)
+	return ""
+}
+
+// inAppContent renders req for the app. reason is set when req cannot be
+// shown in the app at all.
+func inAppContent(req *Request) (title, body string, data map[string]string, reason string, err error) {
+	if req.Recipient.UserID == "" {
+		return "", "", nil, "in-app notifications need a user ID", nil
+	}
+	// In-app notifications read like pushes
+	if _, ok := push.PushTemplates[req.Type]; !ok {
+		return "", "", nil, "no in-app template for " + req.Type, nil
+	}
+	if title, body, err = push.Render(req.Type, req.Data); err != nil {
+		return "", "", nil, "", err
+	}
+	data = make(map[string]string, len(req.Data))
+	for k, v := range req.Data {
+		data[k] = fmt.Sprint(v)
+	}
+	return title, body, data, "", nil
+}
+
+// rateLimited checks the configured limits for one channel's recipient and
+// returns the rate-limited attempt, or nil when the channel may send
+func (d *Dispatcher) rateLimited(ctx context.Context, channel, recipient, notificationType string) *Attempt {
+	appErr, err := d.limiter.Check(ctx, ratelimit.Subject{
+		Recipient: recipient,
+		Type:      notificationType,
+		Caller:    logging.CallerFromContext(ctx),
+	})
+	if err != nil {
+		logging.GetLogger().WithContext(ctx).Warn("rate limit check failed, allowing request", zap.Error(err))
+	}
+	if appErr != nil {
+		return &Attempt{Channel: channel, Status: StatusRateLimited, Reason: appErr.Message, RetryAfter: appErr.RetryAfter}
+	}
+	return nil
+}
+
+// withContactPoints fills contact points the caller left empty from the
+// user's preferences
+func withContactPoints(r Recipient, prefs *preferences.Preferences) Recipient {
+	if r.Email == "" && len(prefs.Emails) > 0 {
+		r.Email = prefs.Emails[0]
+	}
+	if r.Phone == "" && len(prefs.Phones) > 0 {
+		r.Phone = prefs.Phones[0]
+	}
+	if len(r.DeviceTokens) == 0 {
+		r.DeviceTokens = prefs.DeviceTokens
+	}
+	return r
+}
+
+func skipped(attempt Attempt, reason string) Attempt {
+	attempt.Status = StatusSkipped
+	attempt.Reason = reason
+	return attempt
+}
+
+// failed records err, except that suppressed recipients are skipped on
+// purpose rather than failed. Errors that may clear up on their own leave
+// the channel unavailable instead.
+func failed(attempt Attempt, err error) Attempt {
+	if errors.Is(err, suppression.ErrSuppressed) {
+		return skipped(attempt, suppression.ErrSuppressed.Error())
+	}
+	attempt.Status = StatusUnavailable
+	if permanent(err) {
+		attempt.Status = StatusFailed
+	}
+	attempt.Reason = err.Error()
+	return attempt
+}
+
+// permanent reports whether sending again cannot succeed: the request is
+// invalid for the channel, the address is rejected, or the provider says so
+func permanent(err error) bool {
+	for _, target := range []error{
+		email.ErrUnknownType, email.ErrMissingField,
+		sms.ErrUnknownType, sms.ErrMissingField, sms.ErrTooLong, sms.ErrInvalidNumber,
+		push.ErrUnknownType, push.ErrMissingField, push.ErrNoDevices,
+	} {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	// SMTP 5xx replies reject the message for good
+	var smtpErr *textproto.Error
+	if errors.As(err, &smtpErr) && smtpErr.Code >= 500 {
+		return true
+	}
+	return push.Permanent(err)
+}
@@ -0,0 +1,88 @@
+package notify
+
+import (
+	"strings"
+	"time"
+
+	"ride-sharing-notification/config"
+	"ride-sharing-notification/internal/pkg/preferences"
+	"ride-sharing-notification/internal/pkg/quiethours"
+)
+
+// quietSettings is the service-wide quiet hours policy
+type quietSettings struct {
+	enabled    bool
+	window     quiethours.Window
+	location   *time.Location
+	categories map[preferences.Category]bool
+	urgent     map[string]bool
+}
+
+func quietSettingsFromConfig(cfg *config.Config) *quietSettings {
+	q := &quietSettings{
+		enabled:    cfg.QuietHours.Enabled,
+		location:   time.UTC,
+		categories: make(map[preferences.Category]bool),
+		urgent:     make(map[string]bool),
+	}
+	// Values are validated while loading the configuration
+	q.window, _ = quiethours.Parse(cfg.QuietHours.Start, cfg.QuietHours.End)
+	if loc, err := time.LoadLocation(cfg.QuietHours.TimeZone); err == nil {
+		q.location = loc
+	}
+	for _, category := range cfg.QuietHours.Categories {
+		q.categories[preferences.Category(category)] = true
+	}
+	for _, notificationType := range cfg.QuietHours.UrgentTypes {
+		q.urgent[strings.ToUpper(notificationType)] = true
+	}
+	return q
+}
+
+// holdUntil reports whether req falls in the recipient's quiet hours and,
+// if so, when they end. The request's time zone and window take precedence
+// over the user's, which take precedence over the configured defaults.
+func (d *Dispatcher) holdUntil(req *Request, prefs *preferences.Preferences) (time.Time, bool) {
+	q := d.quiet.Load()
+	if !q.enabled || req.Urgent || q.urgent[strings.ToUpper(req.Type)] || !q.categories[preferences.CategoryOf(req.Type)] {
+		return time.Time{}, false
+	}
+
+	window := q.window
+	if prefs != nil && prefs.QuietHours != nil {
+		if w, err := prefs.QuietHours.Window(); err == nil {
+			window = w
+		}
+	}
+	if req.QuietHours != nil {
+		window = *req.QuietHours
+	}
+
+	now := d.now().In(d.location(req, prefs))
+	if window.IsZero() || !window.Contains(now) {
+		return time.Time{}, false
+	}
+	return window.EndAfter(now), true
+}
+
+// location is the recipient's time zone: the request's, else the user's,
+// else the configured default
+func (d *Dispatcher) location(req *Request, prefs *preferences.Preferences) *time.Location {
+	location := d.quiet.Load().location
+	if prefs != nil && prefs.TimeZone != "" {
+		location = loadLocation(prefs.TimeZone, location)
+	}
+	if req.TimeZone != "" {
+		location = loadLocation(req.TimeZone, location)
+	}
+	return location
+}
+
+// loadLocation returns the named time zone, or fallback when it is unknown
+func loadLocation(name string, fallback *time.Location) *time.Location {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return fallback
+	}
+	return loc
+}
@@ -0,0 +1,345 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"ride-sharing-notification/internal/pkg/logging"
+	"ride-sharing-notification/internal/pkg/metrics"
+	"ride-sharing-notification/internal/pkg/storage"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	scheduleBucket = "scheduled"
+	// scheduleIDBucket maps a job ID to its key in scheduleBucket
+	scheduleIDBucket = "scheduled_ids"
+	// dueKeyLayout sorts keys by release time
+	dueKeyLayout = "20060102T150405.000000000Z"
+	// maxReleaseAttempts bounds how often a job whose release keeps failing
+	// is retried
+	maxReleaseAttempts = 5
+)
+
+// Reasons a notification is held back
+const (
+	ReasonQuietHours = "quiet_hours"
+	ReasonScheduled  = "scheduled"
+	ReasonDigest     = "digest"
+)
+
+var (
+	// ErrJobNotFound is returned for unknown, cancelled or already sent jobs
+	ErrJobNotFound = errors.New("scheduled notification not found")
+
+	// errStopScan ends a scan early without reporting an error
+	errStopScan = errors.New("stop scan")
+)
+
+// Job is a notification waiting for its release time
+type Job struct {
+	ID        string    `json:"id"`
+	DueAt     time.Time `json:"due_at"`
+	Reason    string    `json:"reason"`
+	Request   Request   `json:"request"`
+	CreatedAt time.Time `json:"created_at"`
+	// Attempts counts releases that were started; a job whose release was
+	// interrupted comes due again when its lease runs out
+	Attempts int `json:"attempts"`
+}
+
+func (j *Job) key() string {
+	return j.DueAt.UTC().Format(dueKeyLayout) + "/" + j.ID
+}
+
+// Scheduler stores notifications that are sent later. Jobs are keyed by
+// release time so the due ones are found with a single ordered scan.
+//
+// Release is at-least-once: claiming a job does not delete it but moves its
+// release time one lease into the future, and it is only removed once it
+// has been sent. If the process dies in between, the job comes due again.
+type Scheduler struct {
+	kv    storage.KV
+	lease time.Duration
+	now   func() time.Time
+}
+
+func NewScheduler(kv storage.KV, lease time.Duration) *Scheduler {
+	return &Scheduler{kv: kv, lease: lease, now: time.Now}
+}
+
+// Add stores req for release at dueAt
+func (s *Scheduler) Add(ctx context.Context, req Request, dueAt time.Time, reason string) (*Job, error) {
+	job := &Job{
+		ID:        uuid.New().String(),
+		DueAt:     dueAt.UTC(),
+		Reason:    reason,
+		Request:   req,
+		CreatedAt: s.now().UTC(),
+	}
+	if err := s.put(ctx, job); err != nil {
+		return nil, fmt.Errorf("schedule notification: %w", err)
+	}
+	return job, nil
+}
+
+// Get returns the job with id
+func (s *Scheduler) Get(ctx context.Context, id string) (*Job, error) {
+	key, err := s.keyOf(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	job := &Job{}
+	if err := storage.GetJSON(ctx, s.kv, scheduleBucket, key, job); err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, ErrJobNotFound
+		}
+		return nil, err
+	}
+	return job, nil
+}
+
+// Cancel removes the job with id so it is never sent
+func (s *Scheduler) Cancel(ctx context.Context, id string) error {
+	job, err := s.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	return s.remove(ctx, job)
+}
+
+// Reschedule moves the job with id to dueAt
+func (s *Scheduler) Reschedule(ctx context.Context, id string, dueAt time.Time) (*Job, error) {
+	job, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.move(ctx, job, dueAt.UTC(), job.Attempts)
+}
+
+// Due returns the jobs whose release time is not after now, oldest first
+func (s *Scheduler) Due(ctx context.Context, now time.Time) ([]Job, error) {
+	var jobs []Job
+	cutoff := now.UTC().Format(dueKeyLayout) + "0"
+	err := s.kv.Scan(ctx, scheduleBucket, "", func(key string, value []byte) error {
+		if key > cutoff {
+			return errStopScan
+		}
+		var job Job
+		if err := json.Unmarshal(value, &job); err != nil {
+			return fmt.Errorf("decode scheduled notification %s: %w", key, err)
+		}
+		jobs = append(jobs, job)
+		return nil
+	})
+	if err != nil && !errors.Is(err, errStopScan) {
+		return nil, err
+	}
+	return jobs, nil
+}
+
+// Claim leases job for release and returns the leased copy, or nil when
+// another caller claimed, cancelled or rescheduled it first
+func (s *Scheduler) Claim(ctx context.Context, job *Job) (*Job, error) {
+	leased, err := s.move(ctx, job, s.now().Add(s.lease).UTC(), job.Attempts+1)
+	if errors.Is(err, ErrJobNotFound) {
+		return nil, nil
+	}
+	return leased, err
+}
+
+// Complete removes a released job unless it was rescheduled meanwhile
+func (s *Scheduler) Complete(ctx context.Context, job *Job) error {
+	return s.remove(ctx, job)
+}
+
+// move stores job under a new release time. It fails with ErrJobNotFound
+// when job is no longer stored under its current key.
+func (s *Scheduler) move(ctx context.Context, job *Job, dueAt time.Time, attempts int) (*Job, error) {
+	moved := *job
+	moved.DueAt = dueAt
+	moved.Attempts = attempts
+	if moved.key() == job.key() {
+		if _, err := s.Get(ctx, job.ID); err != nil {
+			return nil, err
+		}
+		return &moved, storage.PutJSON(ctx, s.kv, scheduleBucket, moved.key(), &moved)
+	}
+
+	// Write the new copy first: a crash between the two steps leaves a
+	// duplicate rather than losing the job
+	if err := storage.PutJSON(ctx, s.kv, scheduleBucket, moved.key(), &moved); err != nil {
+		return nil, err
+	}
+	existed := false
+	err := s.kv.Update(ctx, scheduleBucket, job.key(), func(current []byte) ([]byte, error) {
+		existed = current != nil
+		return nil, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !existed {
+		_ = s.kv.Delete(ctx, scheduleBucket, moved.key())
+		return nil, ErrJobNotFound
+	}
+	if err := s.kv.Put(ctx, scheduleIDBucket, moved.ID, []byte(moved.key())); err != nil {
+		return nil, err
+	}
+	return &moved, nil
+}
+
+func (s *Scheduler) put(ctx context.Context, job *Job) error {
+	if err := storage.PutJSON(ctx, s.kv, scheduleBucket, job.key(), job); err != nil {
+		return err
+	}
+	return s.kv.Put(ctx, scheduleIDBucket, job.ID, []byte(job.key()))
+}
+
+// remove deletes job and, if it still points at job, its ID index entry
+func (s *Scheduler) remove(ctx context.Context, job *Job) error {
+	key := job.key()
+	if err := s.kv.Delete(ctx, scheduleBucket, key); err != nil {
+		return err
+	}
+	return s.kv.Update(ctx, scheduleIDBucket, job.ID, func(current []byte) ([]byte, error) {
+		if string(current) == key {
+			return nil, nil
+		}
+		return current, nil
+	})
+}
+
+func (s *Scheduler) keyOf(ctx context.Context, id string) (string, error) {
+	key, err := s.kv.Get(ctx, scheduleIDBucket, id)
+	if errors.Is(err, storage.ErrNotFound) {
+		return "", ErrJobNotFound
+	}
+	if err != nil {
+		return "", err
+	}
+	return string(key), nil
+}
+
+// Schedule stores req to be sent at sendAt. Preferences, quiet hours and
+// contact points are resolved when it is released, not now.
+func (d *Dispatcher) Schedule(ctx context.Context, req *Request, sendAt time.Time) (*Job, error) {
+	if err := ValidateChannels(req.Channels); err != nil {
+		return nil, err
+	}
+	job, err := d.scheduler.Add(ctx, *req, sendAt, ReasonScheduled)
+	if err != nil {
+		return nil, err
+	}
+	metrics.NotificationsHeldTotal.WithLabelValues(req.Type, ReasonScheduled).Inc()
+	return job, nil
+}
+
+// hold stores req until dueAt instead of sending it. A request that is
+// being released from job keeps the job's ID.
+func (d *Dispatcher) hold(ctx context.Context, req *Request, job *Job, dueAt time.Time, reason string) (*Outcome, error) {
+	var err error
+	if job != nil {
+		job, err = d.scheduler.Reschedule(ctx, job.ID, dueAt)
+	} else {
+		job, err = d.scheduler.Add(ctx, *req, dueAt, reason)
+	}
+	if err != nil {
+		return nil, err
+	}
+	metrics.NotificationsHeldTotal.WithLabelValues(req.Type, reason).Inc()
+	logging.GetLogger().WithContext(ctx).Info("holding notification",
+		zap.String("type", req.Type),
+		zap.String("user_id", req.Recipient.UserID),
+		zap.String("reason", reason),
+		zap.Time("due_at", job.DueAt),
+		zap.String("schedule_id", job.ID),
+	)
+	return &Outcome{HeldUntil: job.DueAt, HeldReason: reason, ScheduleID: job.ID}, nil
+}
+
+// Run releases held and scheduled notifications and digests as they fall
+// due, checking every interval until ctx is done
+func (d *Dispatcher) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			d.releaseDue(ctx)
+		}
+	}
+}
+
+func (d *Dispatcher) releaseDue(ctx context.Context) {
+	logger := logging.GetLogger()
+	jobs, err := d.scheduler.Due(ctx, d.now())
+	if err != nil {
+		logger.Error("failed to load scheduled notifications", zap.Error(err))
+		return
+	}
+
+	for i := range jobs {
+		if ctx.Err() != nil {
+			return
+		}
+		job, err := d.scheduler.Claim(ctx, &jobs[i])
+		if err != nil {
+			logger.Error("failed to claim scheduled notification", zap.String("schedule_id", jobs[i].ID), zap.Error(err))
+			continue
+		}
+		if job == nil {
+			continue
+		}
+		d.release(ctx, job)
+	}
+}
+
+// release sends a claimed job. The request goes through the full dispatch
+// again, so preferences and quiet hours changed in the meantime apply.
+func (d *Dispatcher) release(ctx context.Context, job *Job) {
+	logger := logging.GetLogger().With(
+		zap.String("schedule_id", job.ID),
+		zap.String("type", job.Request.Type),
+	)
+
+	var outcome *Outcome
+	var err error
+	if job.Reason == ReasonDigest {
+		outcome, err = d.sendDigest(ctx, job)
+	} else {
+		// Failed releases are retried, so the job stands in for a missing
+		// request ID
+		if job.Request.ID == "" {
+			job.Request.ID = job.ID
+		}
+		outcome, err = d.send(ctx, &job.Request, job)
+	}
+	if err != nil {
+		if job.Attempts < maxReleaseAttempts {
+			// The lease brings the job back for another attempt
+			logger.Error("failed to release scheduled notification, retrying later",
+				zap.Int("attempt", job.Attempts), zap.Error(err))
+			return
+		}
+		logger.Error("giving up on scheduled notification", zap.Int("attempts", job.Attempts), zap.Error(err))
+	}
+	if err := d.scheduler.Complete(context.WithoutCancel(ctx), job); err != nil {
+		logger.Error("failed to remove released notification", zap.Error(err))
+		return
+	}
+	if outcome != nil {
+		logger.Info("released scheduled notification",
+			zap.String("channel", outcome.Channel),
+			zap.Bool("opted_out", outcome.OptedOut),
+			zap.Bool("held_again", outcome.Held()),
+		)
+	}
+}
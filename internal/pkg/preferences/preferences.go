@@ -0,0 +1,244 @@
+package preferences
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"ride-sharing-notification/internal/pkg/errors"
+	"ride-sharing-notification/internal/pkg/quiethours"
+	"ride-sharing-notification/internal/pkg/sms"
+	"ride-sharing-notification/internal/pkg/storage"
+)
+
+const bucket = "preferences"
+
+// Category groups notification types a user can opt in or out of together
+type Category string
+
+const (
+	// CategoryTransactional covers account security messages such as OTPs.
+	// They are mandatory and cannot be turned off.
+	CategoryTransactional Category = "transactional"
+	CategoryRideUpdates   Category = "ride_updates"
+	CategoryMarketing     Category = "marketing"
+	// CategoryDriverUpdates covers payouts, ratings and document reminders
+	CategoryDriverUpdates Category = "driver_updates"
+)
+
+// Categories lists every known category
+var Categories = []Category{CategoryTransactional, CategoryRideUpdates, CategoryMarketing, CategoryDriverUpdates}
+
+// typeCategories maps notification types to their category. Types not
+// listed are treated as marketing, so opt-outs err on the side of silence.
+var typeCategories = map[string]Category{
+	"USER_REGISTER":     CategoryTransactional,
+	"FORGET_PASSWORD":   CategoryTransactional,
+	"RESET_PASSWORD":    CategoryTransactional,
+	"PAYMENT_FAILED":    CategoryTransactional,
+	"TRIP_RECEIPT":      CategoryTransactional,
+	"RIDE_REQUESTED":    CategoryRideUpdates,
+	"DRIVER_ASSIGNED":   CategoryRideUpdates,
+	"DRIVER_ARRIVING":   CategoryRideUpdates,
+	"TRIP_STARTED":      CategoryRideUpdates,
+	"TRIP_COMPLETED":    CategoryRideUpdates,
+	"RIDE_CANCELLED":    CategoryRideUpdates,
+	"DRIVER_PAYOUT":     CategoryDriverUpdates,
+	"DRIVER_RATING":     CategoryDriverUpdates,
+	"DOCUMENT_REMINDER": CategoryDriverUpdates,
+}
+
+// CategoryOf returns the category a notification type belongs to
+func CategoryOf(notificationType string) Category {
+	if c, ok := typeCategories[strings.ToUpper(notificationType)]; ok {
+		return c
+	}
+	return CategoryMarketing
+}
+
+// Mandatory reports whether a notification type is sent regardless of the
+// user's preferences
+func Mandatory(notificationType string) bool {
+	return CategoryOf(notificationType) == CategoryTransactional
+}
+
+// Preferences are a user's contact points and notification choices
+type Preferences struct {
+	UserID       string   `json:"user_id"`
+	Emails       []string `json:"emails,omitempty"`
+	Phones       []string `json:"phones,omitempty"`
+	DeviceTokens []string `json:"device_tokens,omitempty"`
+	// Categories records the opt-in state of each category
+	Categories map[Category]bool `json:"categories"`
+	// Channels is the preferred delivery order, e.g. push, sms, email
+	Channels []string `json:"channels,omitempty"`
+	Locale   string   `json:"locale,omitempty"`
+	// TimeZone is an IANA name such as Asia/Ho_Chi_Minh
+	TimeZone   string      `json:"time_zone,omitempty"`
+	QuietHours *QuietHours `json:"quiet_hours,omitempty"`
+	UpdatedAt  time.Time   `json:"updated_at"`
+}
+
+// QuietHours overrides the service-wide quiet hours for one user. Equal
+// start and end turn quiet hours off.
+type QuietHours struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+// Window parses the quiet hours
+func (q *QuietHours) Window() (quiethours.Window, error) {
+	return quiethours.Parse(q.Start, q.End)
+}
+
+// Default returns the preferences of a user who never changed them:
+// ride and driver updates on, marketing off until the user opts in
+func Default(userID string) *Preferences {
+	return &Preferences{
+		UserID: userID,
+		Categories: map[Category]bool{
+			CategoryTransactional: true,
+			CategoryRideUpdates:   true,
+			CategoryMarketing:     false,
+			CategoryDriverUpdates: true,
+		},
+	}
+}
+
+// OptedIn reports whether the user receives notifications of category
+func (p *Preferences) OptedIn(c Category) bool {
+	if c == CategoryTransactional {
+		return true
+	}
+	if optedIn, ok := p.Categories[c]; ok {
+		return optedIn
+	}
+	return Default(p.UserID).Categories[c]
+}
+
+// Allows reports whether notificationType may be sent to the user
+func (p *Preferences) Allows(notificationType string) bool {
+	return p.OptedIn(CategoryOf(notificationType))
+}
+
+// Validate normalises contact points and reports every invalid field
+func (p *Preferences) Validate() error {
+	violations := map[string]string{}
+
+	for i, address := range p.Emails {
+		address = strings.ToLower(strings.TrimSpace(address))
+		if _, domain, ok := strings.Cut(address, "@"); !ok || domain == "" {
+			violations["emails"] = fmt.Sprintf("%q is not an email address", address)
+		}
+		p.Emails[i] = address
+	}
+	for i, phone := range p.Phones {
+		normalized, err := sms.NormalizeE164(phone)
+		if err != nil {
+			violations["phones"] = err.Error()
+			continue
+		}
+		p.Phones[i] = normalized
+	}
+	for c, optedIn := range p.Categories {
+		switch {
+		case !slices.Contains(Categories, c):
+			violations["categories"] = fmt.Sprintf("unknown category %q", c)
+		case c == CategoryTransactional && !optedIn:
+			violations["categories"] = "transactional notifications cannot be turned off"
+		}
+	}
+	for _, channel := range p.Channels {
+		if channel != "email" && channel != "sms" && channel != "push" {
+			violations["channels"] = fmt.Sprintf("unknown channel %q", channel)
+		}
+	}
+	if p.TimeZone != "" {
+		if _, err := time.LoadLocation(p.TimeZone); err != nil {
+			violations["time_zone"] = fmt.Sprintf("unknown time zone %q", p.TimeZone)
+		}
+	}
+	if p.QuietHours != nil {
+		if _, err := p.QuietHours.Window(); err != nil {
+			violations["quiet_hours"] = err.Error()
+		}
+	}
+
+	if len(violations) > 0 {
+		return errors.NewValidationError("invalid preferences", violations)
+	}
+	p.Emails = dedupe(p.Emails)
+	p.Phones = dedupe(p.Phones)
+	p.DeviceTokens = dedupe(p.DeviceTokens)
+	return nil
+}
+
+type Service struct {
+	kv  storage.KV
+	now func() time.Time
+}
+
+func NewService(kv storage.KV) *Service {
+	return &Service{kv: kv, now: time.Now}
+}
+
+// Get returns the stored preferences, or the defaults for unknown users
+func (s *Service) Get(ctx context.Context, userID string) (*Preferences, error) {
+	prefs := Default(userID)
+	err := storage.GetJSON(ctx, s.kv, bucket, userID, prefs)
+	if err != nil && !stderrors.Is(err, storage.ErrNotFound) {
+		return nil, fmt.Errorf("load preferences: %w", err)
+	}
+	return prefs, nil
+}
+
+// Update applies fn to the user's preferences and stores the result
+// atomically. Validation failures are returned as *errors.AppError.
+func (s *Service) Update(ctx context.Context, userID string, fn func(p *Preferences) error) (*Preferences, error) {
+	var updated *Preferences
+	err := s.kv.Update(ctx, bucket, userID, func(current []byte) ([]byte, error) {
+		prefs := Default(userID)
+		if current != nil {
+			if err := json.Unmarshal(current, prefs); err != nil {
+				return nil, fmt.Errorf("decode preferences: %w", err)
+			}
+		}
+
+		if err := fn(prefs); err != nil {
+			return nil, err
+		}
+		if err := prefs.Validate(); err != nil {
+			return nil, err
+		}
+		prefs.UserID = userID
+		if prefs.Categories == nil {
+			prefs.Categories = make(map[Category]bool)
+		}
+		prefs.Categories[CategoryTransactional] = true
+		prefs.UpdatedAt = s.now().UTC()
+
+		updated = prefs
+		return json.Marshal(prefs)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+func dedupe(values []string) []string {
+	seen := make(map[string]struct{}, len(values))
+	out := values[:0]
+	for _, v := range values {
+		if _, ok := seen[v]; ok || v == "" {
+			continue
+		}
+		seen[v] = struct{}{}
+		out = append(out, v)
+	}
+	return out
+}
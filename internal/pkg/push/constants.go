@@ -0,0 +1,115 @@
+package push
+
+import "text/template"
+
+const (
+	PushTypeDriverArriving = "DRIVER_ARRIVING"
+
+	// Ride lifecycle
+	PushTypeRideRequested  = "RIDE_REQUESTED"
+	PushTypeDriverAssigned = "DRIVER_ASSIGNED"
+	PushTypeTripStarted    = "TRIP_STARTED"
+	PushTypeTripCompleted  = "TRIP_COMPLETED"
+	PushTypeRideCancelled  = "RIDE_CANCELLED"
+	PushTypePaymentFailed  = "PAYMENT_FAILED"
+
+	// Driver account
+	PushTypeDriverPayout     = "DRIVER_PAYOUT"
+	PushTypeDriverRating     = "DRIVER_RATING"
+	PushTypeDocumentReminder = "DOCUMENT_REMINDER"
+
+	// PushTypeDigest combines notifications batched over a window
+	PushTypeDigest = "DIGEST"
+)
+
+// Payload is a push notification for one or more devices of the same
+// user. Title and Body are sent as-is when set; otherwise the template
+// registered for Type is rendered with Data.
+type Payload struct {
+	Tokens []string
+	Type   string
+	Title  string
+	Body   string
+	Data   map[string]interface{}
+}
+
+type PushTemplate struct {
+	Title          string
+	Body           string
+	RequiredFields []string
+}
+
+var PushTemplates = map[string]PushTemplate{
+	PushTypeDriverArriving: {
+		Title:          "Your driver is arriving",
+		Body:           "{{.driver_name}} will arrive in {{.eta_minutes}} min in a {{.vehicle}} ({{.plate}}).",
+		RequiredFields: []string{"driver_name", "eta_minutes", "vehicle", "plate"},
+	},
+	PushTypeRideRequested: {
+		Title:          "Finding your driver",
+		Body:           "We're matching you with a driver for your ride to {{.dropoff}}.",
+		RequiredFields: []string{"pickup", "dropoff"},
+	},
+	PushTypeDriverAssigned: {
+		Title:          "{{.driver_name}} is on the way",
+		Body:           "{{.vehicle}} ({{.plate}}) will pick you up in about {{.eta_minutes}} min.",
+		RequiredFields: []string{"driver_name", "vehicle", "plate", "eta_minutes"},
+	},
+	PushTypeTripStarted: {
+		Title:          "Trip started",
+		Body:           "Enjoy your ride to {{.dropoff}} with {{.driver_name}}.",
+		RequiredFields: []string{"driver_name", "dropoff"},
+	},
+	PushTypeTripCompleted: {
+		Title:          "You've arrived",
+		Body:           "Your fare to {{.dropoff}} was {{.fare}}. Thanks for riding with us!",
+		RequiredFields: []string{"fare", "dropoff"},
+	},
+	// fee is optional; it is only mentioned when present
+	PushTypeRideCancelled: {
+		Title:          "Ride cancelled",
+		Body:           "Your ride was cancelled by {{.cancelled_by}}.{{with index . \"fee\"}} A cancellation fee of {{.}} was charged.{{end}}",
+		RequiredFields: []string{"cancelled_by"},
+	},
+	PushTypePaymentFailed: {
+		Title:          "Payment failed",
+		Body:           "We couldn't charge {{.amount}} for your ride. Update your payment method to keep riding.",
+		RequiredFields: []string{"amount"},
+	},
+	PushTypeDriverPayout: {
+		Title:          "Payout sent",
+		Body:           "{{.amount}} is on its way to your bank account.",
+		RequiredFields: []string{"amount"},
+	},
+	PushTypeDriverRating: {
+		Title:          "New rating",
+		Body:           "A rider rated your trip {{.rating}} stars.{{with index . \"comment\"}} \"{{.}}\"{{end}}",
+		RequiredFields: []string{"rating"},
+	},
+	PushTypeDocumentReminder: {
+		Title:          "Document expiring soon",
+		Body:           "Your {{.document}} expires on {{.expires_on}}. Upload a new one to keep driving.",
+		RequiredFields: []string{"document", "expires_on"},
+	},
+	PushTypeDigest: {
+		Title:          "You have {{.count}} updates",
+		Body:           "{{.summary}}",
+		RequiredFields: []string{"count", "summary"},
+	},
+}
+
+type parsedTemplate struct {
+	title *template.Template
+	body  *template.Template
+}
+
+var parsedTemplates = func() map[string]parsedTemplate {
+	parsed := make(map[string]parsedTemplate, len(PushTemplates))
+	for name, t := range PushTemplates {
+		parsed[name] = parsedTemplate{
+			title: template.Must(template.New(name + ".title").Option("missingkey=error").Parse(t.Title)),
+			body:  template.Must(template.New(name + ".body").Option("missingkey=error").Parse(t.Body)),
+		}
+	}
+	return parsed
+}()
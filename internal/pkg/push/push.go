@@ -0,0 +1,267 @@
+package push
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"ride-sharing-notification/config"
+	"ride-sharing-notification/internal/pkg/logging"
+	"ride-sharing-notification/internal/pkg/metrics"
+	"ride-sharing-notification/internal/pkg/suppression"
+	"ride-sharing-notification/internal/pkg/throttle"
+	"ride-sharing-notification/internal/pkg/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+const (
+	channelName = "push"
+	maxAttempts = 2
+	retryDelay  = 500 * time.Millisecond
+)
+
+var (
+	// ErrDisabled is returned when the push channel is switched off
+	ErrDisabled = errors.New("push channel is disabled")
+	// ErrUnknownType is returned for a payload without title whose type has no template
+	ErrUnknownType = errors.New("unknown push type")
+	// ErrMissingField is returned when template data lacks a required field
+	ErrMissingField = errors.New("missing required field")
+	// ErrNoDevices is returned when the payload lists no device tokens
+	ErrNoDevices = errors.New("no device tokens")
+	// ErrNotDelivered is returned when no device accepted the notification
+	ErrNotDelivered = errors.New("push not delivered to any device")
+)
+
+// Provider delivers a notification to one device and returns the
+// provider's message ID. Errors may implement Permanent() bool and
+// Unregistered() bool to control retries.
+type Provider interface {
+	Name() string
+	Send(ctx context.Context, token, title, body string, data map[string]string) (string, error)
+}
+
+// Pruner forgets device tokens the provider reports as unregistered
+type Pruner interface {
+	Remove(ctx context.Context, token string) error
+}
+
+// Delivery is a device that accepted the notification
+type Delivery struct {
+	Token     string
+	MessageID string
+}
+
+// Failure is a device that did not
+type Failure struct {
+	Token string
+	Err   error
+	// Unregistered means the token is no longer valid
+	Unregistered bool
+}
+
+// Result lists the outcome for every device
+type Result struct {
+	Provider  string
+	Delivered []Delivery
+	Failed    []Failure
+}
+
+type Service struct {
+	provider  Provider
+	throttler *throttle.Throttler
+	pruner    Pruner
+	// suppressions are device tokens that are never sent to
+	suppressions *suppression.List
+	enabled      atomic.Bool
+}
+
+// NewService creates the push channel. pruner may be nil when tokens are
+// not stored by the service.
+func NewService(provider Provider, throttler *throttle.Throttler, pruner Pruner, suppressions *suppression.List) *Service {
+	s := &Service{
+		provider:     provider,
+		throttler:    throttler,
+		pruner:       pruner,
+		suppressions: suppressions,
+	}
+	s.enabled.Store(provider != nil)
+	return s
+}
+
+// ApplyConfig switches the channel on or off
+func (s *Service) ApplyConfig(cfg *config.Config) {
+	s.enabled.Store(cfg.Push.Enabled && s.provider != nil)
+}
+
+// Enabled reports whether push notifications are currently being sent
+func (s *Service) Enabled() bool {
+	return s.enabled.Load()
+}
+
+// Send delivers p to every device token. It succeeds when at least one
+// device accepted the notification; per-device failures are in the result.
+// Unregistered tokens are pruned.
+func (s *Service) Send(ctx context.Context, p *Payload) (result *Result, err error) {
+	start := time.Now()
+	ctx, span := tracing.Start(ctx, "push.send",
+		trace.WithAttributes(
+			attribute.String("notification.type", p.Type),
+			attribute.Int("push.devices", len(p.Tokens)),
+		),
+	)
+	defer func() {
+		tracing.RecordError(span, err)
+		span.End()
+
+		status := metrics.StatusSent
+		switch {
+		case errors.Is(err, ErrDisabled), errors.Is(err, suppression.ErrSuppressed):
+			status = metrics.StatusSkipped
+		case err != nil:
+			status = metrics.StatusFailed
+		}
+		metrics.SendsTotal.WithLabelValues(channelName, p.Type, status).Inc()
+		metrics.SendDuration.WithLabelValues(channelName, p.Type).Observe(time.Since(start).Seconds())
+	}()
+
+	if !s.Enabled() {
+		return nil, ErrDisabled
+	}
+	if len(p.Tokens) == 0 {
+		return nil, ErrNoDevices
+	}
+	// Suppressed devices are left out; the send is only skipped when every
+	// device is suppressed
+	tokens := make([]string, 0, len(p.Tokens))
+	var suppressed error
+	for _, token := range p.Tokens {
+		if err := s.suppressions.Check(ctx, suppression.KindDeviceToken, token); err != nil {
+			if !errors.Is(err, suppression.ErrSuppressed) {
+				return nil, err
+			}
+			suppressed = err
+			continue
+		}
+		tokens = append(tokens, token)
+	}
+	if len(tokens) == 0 {
+		return nil, suppressed
+	}
+
+	title, body := p.Title, p.Body
+	if title == "" {
+		if title, body, err = Render(p.Type, p.Data); err != nil {
+			return nil, err
+		}
+	}
+
+	data := make(map[string]string, len(p.Data))
+	for k, v := range p.Data {
+		data[k] = fmt.Sprint(v)
+	}
+
+	result = &Result{Provider: s.provider.Name()}
+	for _, token := range tokens {
+		messageID, err := s.sendToDevice(ctx, p.Type, token, title, body, data)
+		if err != nil {
+			var unregistered interface{ Unregistered() bool }
+			failure := Failure{
+				Token:        token,
+				Err:          err,
+				Unregistered: errors.As(err, &unregistered) && unregistered.Unregistered(),
+			}
+			if failure.Unregistered {
+				s.prune(ctx, token)
+			}
+			result.Failed = append(result.Failed, failure)
+			if ctx.Err() != nil {
+				return result, ctx.Err()
+			}
+			continue
+		}
+		result.Delivered = append(result.Delivered, Delivery{Token: token, MessageID: messageID})
+	}
+
+	if len(result.Delivered) == 0 {
+		return result, fmt.Errorf("%w: %w", ErrNotDelivered, result.Failed[len(result.Failed)-1].Err)
+	}
+	return result, nil
+}
+
+// Permanent reports whether err means retrying, or trying another device
+// of the same kind, cannot succeed
+func Permanent(err error) bool {
+	var permanent interface{ Permanent() bool }
+	return errors.As(err, &permanent) && permanent.Permanent()
+}
+
+func (s *Service) prune(ctx context.Context, token string) {
+	if s.pruner == nil {
+		return
+	}
+	if err := s.pruner.Remove(ctx, token); err != nil {
+		logging.GetLogger().WithContext(ctx).Warn("failed to prune unregistered device token", zap.Error(err))
+		return
+	}
+	metrics.DeviceTokensPrunedTotal.Inc()
+}
+
+func (s *Service) sendToDevice(ctx context.Context, pushType, token, title, body string, data map[string]string) (messageID string, err error) {
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		if attempt > 1 {
+			metrics.SendRetriesTotal.WithLabelValues(channelName, pushType).Inc()
+			time.Sleep(retryDelay)
+		}
+
+		// Queue behind the provider's throughput limit
+		key := channelName + ":" + s.provider.Name()
+		waited, waitErr := s.throttler.Wait(ctx, key)
+		metrics.ThrottleWait.WithLabelValues(key).Observe(waited.Seconds())
+		if waitErr != nil {
+			return "", fmt.Errorf("waiting for send slot: %w", waitErr)
+		}
+
+		messageID, err = s.provider.Send(ctx, token, title, body, data)
+		result := "ok"
+		if err != nil {
+			result = "error"
+		}
+		metrics.ProviderRequestsTotal.WithLabelValues(channelName, s.provider.Name(), result).Inc()
+
+		if err == nil || Permanent(err) || ctx.Err() != nil {
+			return messageID, err
+		}
+	}
+	return "", err
+}
+
+// Render renders the title and body template registered for pushType
+func Render(pushType string, data map[string]interface{}) (string, string, error) {
+	tmpl, ok := parsedTemplates[pushType]
+	if !ok {
+		return "", "", fmt.Errorf("%w: %s", ErrUnknownType, pushType)
+	}
+	for _, field := range PushTemplates[pushType].RequiredFields {
+		if _, ok := data[field]; !ok {
+			return "", "", fmt.Errorf("%w %q for %s", ErrMissingField, field, pushType)
+		}
+	}
+
+	var title, body bytes.Buffer
+	if err := tmpl.title.Execute(&title, data); err != nil {
+		metrics.TemplateRenderErrorsTotal.WithLabelValues(pushType).Inc()
+		return "", "", fmt.Errorf("failed to render template: %w", err)
+	}
+	if err := tmpl.body.Execute(&body, data); err != nil {
+		metrics.TemplateRenderErrorsTotal.WithLabelValues(pushType).Inc()
+		return "", "", fmt.Errorf("failed to render template: %w", err)
+	}
+	return title.String(), body.String(), nil
+}
@@ -0,0 +1,59 @@
+package quiethours
+
+import (
+	"fmt"
+	"time"
+)
+
+const clockLayout = "15:04"
+
+// Window is a daily period in the recipient's local time during which
+// non-urgent notifications are held. A window whose end is before its start
+// spans midnight, e.g. 22:00–07:00.
+type Window struct {
+	// Start and End are minutes after local midnight
+	Start int
+	End   int
+}
+
+// Parse reads a window from two "HH:MM" clock times
+func Parse(start, end string) (Window, error) {
+	s, err := time.Parse(clockLayout, start)
+	if err != nil {
+		return Window{}, fmt.Errorf("invalid quiet hours start %q: want HH:MM", start)
+	}
+	e, err := time.Parse(clockLayout, end)
+	if err != nil {
+		return Window{}, fmt.Errorf("invalid quiet hours end %q: want HH:MM", end)
+	}
+	return Window{Start: s.Hour()*60 + s.Minute(), End: e.Hour()*60 + e.Minute()}, nil
+}
+
+// IsZero reports whether the window is empty
+func (w Window) IsZero() bool {
+	return w.Start == w.End
+}
+
+// Contains reports whether t, in its own location, falls inside the window
+func (w Window) Contains(t time.Time) bool {
+	m := t.Hour()*60 + t.Minute()
+	if w.Start <= w.End {
+		return m >= w.Start && m < w.End
+	}
+	return m >= w.Start || m < w.End
+}
+
+// EndAfter returns the first end of the window after t, in t's location.
+// Callers check Contains first; the result is only meaningful inside the
+// window.
+func (w Window) EndAfter(t time.Time) time.Time {
+	end := time.Date(t.Year(), t.Month(), t.Day(), w.End/60, w.End%60, 0, 0, t.Location())
+	if !end.After(t) {
+		end = time.Date(t.Year(), t.Month(), t.Day()+1, w.End/60, w.End%60, 0, 0, t.Location())
+	}
+	return end
+}
+
+func (w Window) String() string {
+	return fmt.Sprintf("%02d:%02d-%02d:%02d", w.Start/60, w.Start%60, w.End/60, w.End%60)
+}
@@ -0,0 +1,59 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const sweepInterval = time.Minute
+
+type counter struct {
+	count   int64
+	resetAt time.Time
+}
+
+// MemoryStore is an in-process Store. Limits are enforced per replica.
+type MemoryStore struct {
+	mu        sync.Mutex
+	counters  map[string]*counter
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		counters: make(map[string]*counter),
+		now:      time.Now,
+	}
+}
+
+func (m *MemoryStore) Increment(_ context.Context, key string, window time.Duration) (int64, time.Time, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	m.sweep(now)
+
+	c, ok := m.counters[key]
+	if !ok || !now.Before(c.resetAt) {
+		c = &counter{resetAt: now.Add(window)}
+		m.counters[key] = c
+	}
+	c.count++
+
+	return c.count, c.resetAt, nil
+}
+
+// sweep drops expired counters so idle recipients don't accumulate forever
+func (m *MemoryStore) sweep(now time.Time) {
+	if now.Sub(m.lastSweep) < sweepInterval {
+		return
+	}
+	for key, c := range m.counters {
+		if !now.Before(c.resetAt) {
+			delete(m.counters, key)
+		}
+	}
+	m.lastSweep = now
+}
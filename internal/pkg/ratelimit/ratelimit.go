@@ -0,0 +1,206 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"ride-sharing-notification/config"
+	"ride-sharing-notification/internal/pkg/errors"
+	"ride-sharing-notification/internal/pkg/metrics"
+)
+
+// Dimension is a request attribute that a rule partitions its counters by
+type Dimension string
+
+const (
+	DimensionRecipient Dimension = "recipient"
+	DimensionType      Dimension = "type"
+	DimensionCaller    Dimension = "caller"
+)
+
+// Rule caps how many notifications matching Types may be sent per Window,
+// counted separately for every distinct combination of the By dimensions
+type Rule struct {
+	Name   string
+	By     []Dimension
+	Types  []string // empty matches every notification type
+	Limit  int64
+	Window time.Duration
+}
+
+// Subject describes a single notification being checked against the rules
+type Subject struct {
+	Recipient string
+	Type      string
+	Caller    string
+}
+
+// Decision is the outcome of checking a subject against all rules
+type Decision struct {
+	Allowed    bool
+	Rule       string
+	RetryAfter time.Duration
+}
+
+// Store keeps fixed-window hit counters. Implementations must be safe for
+// concurrent use; a shared store (e.g. Redis) makes limits global across replicas.
+type Store interface {
+	// Increment records one hit for key in the window that contains now and
+	// returns the resulting count together with the time the window resets.
+	Increment(ctx context.Context, key string, window time.Duration) (int64, time.Time, error)
+}
+
+type Limiter struct {
+	store Store
+	now   func() time.Time
+
+	mu    sync.RWMutex
+	rules []Rule
+}
+
+func NewLimiter(store Store, rules []Rule) *Limiter {
+	return &Limiter{
+		store: store,
+		rules: rules,
+		now:   time.Now,
+	}
+}
+
+// Allow counts the subject against every matching rule and reports whether
+// it is still within all limits. Rules by caller skip subjects without a
+// caller, such as Kafka events, which would otherwise all share one counter.
+func (l *Limiter) Allow(ctx context.Context, s Subject) (Decision, error) {
+	decision := Decision{Allowed: true}
+	if l == nil {
+		return decision, nil
+	}
+
+	for _, rule := range l.Rules() {
+		if !rule.matches(s.Type) {
+			continue
+		}
+		if s.Caller == "" && slices.Contains(rule.By, DimensionCaller) {
+			continue
+		}
+
+		count, resetAt, err := l.store.Increment(ctx, rule.key(s), rule.Window)
+		if err != nil {
+			return decision, fmt.Errorf("rate limit store: %w", err)
+		}
+
+		if count > rule.Limit {
+			metrics.RateLimitedTotal.WithLabelValues(rule.Name).Inc()
+			retryAfter := resetAt.Sub(l.now())
+			// Report the most restrictive rule when several are exceeded
+			if decision.Allowed || retryAfter > decision.RetryAfter {
+				decision = Decision{
+					Allowed:    false,
+					Rule:       rule.Name,
+					RetryAfter: retryAfter,
+				}
+			}
+		}
+	}
+
+	return decision, nil
+}
+
+// Rules returns the rules currently enforced
+func (l *Limiter) Rules() []Rule {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.rules
+}
+
+// SetRules replaces the enforced rules. Counters of rules that keep their
+// name and dimensions carry over.
+func (l *Limiter) SetRules(rules []Rule) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.rules = rules
+}
+
+// Check is a convenience wrapper around Allow for delivery handlers. Store
+// failures are not treated as limit violations so an unavailable backend
+// never blocks notifications.
+func (l *Limiter) Check(ctx context.Context, s Subject) (*errors.AppError, error) {
+	decision, err := l.Allow(ctx, s)
+	if err != nil || decision.Allowed {
+		return nil, err
+	}
+	return errors.NewRateLimitError(
+		fmt.Sprintf("rate limit %q exceeded for %s", decision.Rule, s.Type),
+		decision.RetryAfter,
+	), nil
+}
+
+func (r Rule) matches(notificationType string) bool {
+	if len(r.Types) == 0 {
+		return true
+	}
+	for _, t := range r.Types {
+		if strings.EqualFold(t, notificationType) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r Rule) key(s Subject) string {
+	parts := []string{"ratelimit", r.Name}
+	for _, dim := range r.By {
+		switch dim {
+		case DimensionRecipient:
+			parts = append(parts, "r="+strings.ToLower(s.Recipient))
+		case DimensionType:
+			parts = append(parts, "t="+s.Type)
+		case DimensionCaller:
+			parts = append(parts, "c="+s.Caller)
+		}
+	}
+	return strings.Join(parts, ":")
+}
+
+// NewFromConfig builds a limiter from the service configuration. A disabled
+// limiter has no rules and allows everything; the store is still created so
+// limits can be switched on at runtime.
+func NewFromConfig(cfg *config.Config) (*Limiter, error) {
+	var store Store
+	switch cfg.RateLimit.Store {
+	case "", "memory":
+		store = NewMemoryStore()
+	default:
+		return nil, fmt.Errorf("unknown rate limit store: %s", cfg.RateLimit.Store)
+	}
+
+	return NewLimiter(store, RulesFromConfig(cfg)), nil
+}
+
+// RulesFromConfig converts the configured rules, returning none when rate
+// limiting is disabled
+func RulesFromConfig(cfg *config.Config) []Rule {
+	if !cfg.RateLimit.Enabled {
+		return nil
+	}
+
+	rules := make([]Rule, 0, len(cfg.RateLimit.Rules))
+	for _, r := range cfg.RateLimit.Rules {
+		by := make([]Dimension, 0, len(r.By))
+		for _, dim := range r.By {
+			by = append(by, Dimension(dim))
+		}
+		rules = append(rules, Rule{
+			Name:   r.Name,
+			By:     by,
+			Types:  r.Types,
+			Limit:  r.Limit,
+			Window: r.Window,
+		})
+	}
+
+	return rules
+}
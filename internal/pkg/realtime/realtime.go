@@ -0,0 +1,251 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"ride-sharing-notification/internal/pkg/metrics"
+	"ride-sharing-notification/internal/pkg/storage"
+)
+
+const (
+	eventBucket = "realtime_events"
+	// seqBucket holds the last event ID issued per user
+	seqBucket = "realtime_seq"
+	// bufferSize is how many events a subscriber may fall behind before it
+	// is disconnected
+	bufferSize = 64
+)
+
+// ErrInvalidCursor is returned for cursors that are not event IDs
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Event is an in-app notification for one user. IDs increase per user, so
+// the last ID a client saw is its cursor for resuming.
+type Event struct {
+	ID        uint64            `json:"id"`
+	UserID    string            `json:"user_id"`
+	Type      string            `json:"type"`
+	Title     string            `json:"title"`
+	Body      string            `json:"body"`
+	Data      map[string]string `json:"data,omitempty"`
+	CreatedAt time.Time         `json:"created_at"`
+	// InboxID is the inbox item stored for the notification, if any
+	InboxID string `json:"inbox_id,omitempty"`
+}
+
+// Cursor is the value clients send back to resume after ID
+func (e *Event) Cursor() string {
+	return strconv.FormatUint(e.ID, 10)
+}
+
+// ParseCursor returns the event ID of cursor; empty means none
+func ParseCursor(cursor string) (uint64, error) {
+	if cursor == "" {
+		return 0, nil
+	}
+	id, err := strconv.ParseUint(cursor, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidCursor, cursor)
+	}
+	return id, nil
+}
+
+// Subscription receives a user's events as they are published. Events
+// stops when the subscriber falls too far behind or is closed; the client
+// then reconnects and resumes from its cursor.
+type Subscription struct {
+	hub    *Hub
+	userID string
+	events chan Event
+	once   sync.Once
+}
+
+func (s *Subscription) Events() <-chan Event {
+	return s.events
+}
+
+// Close unsubscribes and ends Events
+func (s *Subscription) Close() {
+	s.hub.unsubscribe(s)
+}
+
+// Hub fans events out to every stream a user has open on this instance.
+// The most recent events per user are also stored so a reconnecting client
+// can catch up on what it missed.
+type Hub struct {
+	kv          storage.KV
+	replayLimit int
+	heartbeat   time.Duration
+	now         func() time.Time
+
+	// publishMu serializes publishing so streams see IDs in order
+	publishMu   sync.Mutex
+	mu          sync.Mutex
+	subscribers map[string]map[*Subscription]struct{}
+	closed      bool
+}
+
+// NewHub keeps the last replayLimit events per user. heartbeat is how
+// often streams without events are pinged.
+func NewHub(kv storage.KV, replayLimit int, heartbeat time.Duration) *Hub {
+	return &Hub{
+		kv:          kv,
+		replayLimit: replayLimit,
+		heartbeat:   heartbeat,
+		now:         time.Now,
+		subscribers: make(map[string]map[*Subscription]struct{}),
+	}
+}
+
+// Heartbeat is the interval between heartbeats on idle streams
+func (h *Hub) Heartbeat() time.Duration {
+	return h.heartbeat
+}
+
+// Subscribe starts receiving events for userID. After Close it returns a
+// subscription that is already closed.
+func (h *Hub) Subscribe(userID string) *Subscription {
+	sub := &Subscription{hub: h, userID: userID, events: make(chan Event, bufferSize)}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		sub.once.Do(func() { close(sub.events) })
+		return sub
+	}
+	if h.subscribers[userID] == nil {
+		h.subscribers[userID] = make(map[*Subscription]struct{})
+	}
+	h.subscribers[userID][sub] = struct{}{}
+	metrics.RealtimeStreamsActive.Inc()
+	return sub
+}
+
+func (h *Hub) unsubscribe(sub *Subscription) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.removeLocked(sub)
+}
+
+func (h *Hub) removeLocked(sub *Subscription) {
+	sub.once.Do(func() {
+		delete(h.subscribers[sub.userID], sub)
+		if len(h.subscribers[sub.userID]) == 0 {
+			delete(h.subscribers, sub.userID)
+		}
+		close(sub.events)
+		metrics.RealtimeStreamsActive.Dec()
+	})
+}
+
+// Close ends every subscription so open streams return and clients
+// reconnect elsewhere, e.g. before the server shuts down
+func (h *Hub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.closed = true
+	for _, subs := range h.subscribers {
+		for sub := range subs {
+			h.removeLocked(sub)
+		}
+	}
+}
+
+// Connected reports whether userID has a stream open on this instance
+func (h *Hub) Connected(userID string) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.subscribers[userID]) > 0
+}
+
+// Publish stores e for replay and sends it to the user's open streams. It
+// returns the stored event and how many streams it was sent to.
+func (h *Hub) Publish(ctx context.Context, e Event) (*Event, int, error) {
+	h.publishMu.Lock()
+	defer h.publishMu.Unlock()
+
+	id, err := h.nextID(ctx, e.UserID)
+	if err != nil {
+		return nil, 0, err
+	}
+	e.ID = id
+	e.CreatedAt = h.now().UTC()
+	if err := storage.PutJSON(ctx, h.kv, eventBucket, eventKey(e.UserID, id), &e); err != nil {
+		return nil, 0, fmt.Errorf("store in-app event: %w", err)
+	}
+	if id > uint64(h.replayLimit) {
+		// Each publish retires the event that fell out of the replay window
+		_ = h.kv.Delete(ctx, eventBucket, eventKey(e.UserID, id-uint64(h.replayLimit)))
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	sent := 0
+	for sub := range h.subscribers[e.UserID] {
+		select {
+		case sub.events <- e:
+			sent++
+		default:
+			// Too far behind; the client resumes from its cursor
+			h.removeLocked(sub)
+			metrics.RealtimeSlowSubscribersTotal.Inc()
+		}
+	}
+	return &e, sent, nil
+}
+
+// Replay returns the stored events of userID after the event with ID
+// after, oldest first. Events older than the replay window are gone.
+func (h *Hub) Replay(ctx context.Context, userID string, after uint64) ([]Event, error) {
+	var events []Event
+	start := eventKey(userID, after+1)
+	err := h.kv.Scan(ctx, eventBucket, userPrefix(userID), func(key string, value []byte) error {
+		if key < start {
+			return nil
+		}
+		var e Event
+		if err := json.Unmarshal(value, &e); err != nil {
+			return fmt.Errorf("decode in-app event %s: %w", key, err)
+		}
+		events = append(events, e)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+func (h *Hub) nextID(ctx context.Context, userID string) (uint64, error) {
+	var id uint64
+	err := h.kv.Update(ctx, seqBucket, userID, func(current []byte) ([]byte, error) {
+		if current != nil {
+			last, err := strconv.ParseUint(string(current), 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("decode event sequence of %s: %w", userID, err)
+			}
+			id = last
+		}
+		id++
+		return []byte(strconv.FormatUint(id, 10)), nil
+	})
+	return id, err
+}
+
+// eventKey sorts a user's events by ID
+func eventKey(userID string, id uint64) string {
+	return fmt.Sprintf("%s%020d", userPrefix(userID), id)
+}
+
+// userPrefix starts the keys of a user's events. The user ID is escaped so
+// that no user's prefix is the prefix of another user's keys, as it would
+// be for "alice" and "alice/x".
+func userPrefix(userID string) string {
+	return url.PathEscape(userID) + "/"
+}
@@ -0,0 +1,136 @@
+package realtime
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"ride-sharing-notification/internal/pkg/storage"
+)
+
+func TestParseCursor(t *testing.T) {
+	tests := []struct {
+		cursor  string
+		want    uint64
+		wantErr bool
+	}{
+		{cursor: "", want: 0},
+		{cursor: "0", want: 0},
+		{cursor: "42", want: 42},
+		{cursor: "18446744073709551615", want: 18446744073709551615},
+		{cursor: "18446744073709551616", wantErr: true},
+		{cursor: "-1", wantErr: true},
+		{cursor: "1.5", wantErr: true},
+		{cursor: " 7", wantErr: true},
+		{cursor: "abc", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.cursor, func(t *testing.T) {
+			got, err := ParseCursor(tt.cursor)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidCursor) {
+					t.Errorf("ParseCursor(%q) error = %v, want %v", tt.cursor, err, ErrInvalidCursor)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseCursor(%q) error = %v", tt.cursor, err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseCursor(%q) = %d, want %d", tt.cursor, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCursorRoundTrip(t *testing.T) {
+	for _, id := range []uint64{1, 9, 10, 12345678901234567890} {
+		e := Event{ID: id}
+		got, err := ParseCursor(e.Cursor())
+		if err != nil || got != id {
+			t.Errorf("ParseCursor(%q) = %d, %v, want %d", e.Cursor(), got, err, id)
+		}
+	}
+}
+
+func TestReplay(t *testing.T) {
+	ctx := context.Background()
+	hub := NewHub(storage.NewMemory(), 3, time.Minute)
+
+	// The user IDs share prefixes, so their events must still be kept apart
+	published := map[string]int{"alice": 5, "alice/x": 2, "alice%2Fx": 1, "alic": 1}
+	for userID, n := range published {
+		for range n {
+			if _, _, err := hub.Publish(ctx, Event{UserID: userID, Type: "TRIP_STARTED"}); err != nil {
+				t.Fatalf("Publish(%s) error = %v", userID, err)
+			}
+		}
+	}
+
+	tests := []struct {
+		name   string
+		userID string
+		after  uint64
+		want   []uint64
+	}{
+		{"from the start keeps only the replay window", "alice", 0, []uint64{3, 4, 5}},
+		{"after an event that is gone", "alice", 1, []uint64{3, 4, 5}},
+		{"after a recent event", "alice", 3, []uint64{4, 5}},
+		{"caught up", "alice", 5, nil},
+		{"cursor ahead of the stream", "alice", 100, nil},
+		{"user ID containing a slash", "alice/x", 0, []uint64{1, 2}},
+		{"user ID containing an escape", "alice%2Fx", 0, []uint64{1}},
+		{"shorter user ID", "alic", 0, []uint64{1}},
+		{"unknown user", "bob", 0, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events, err := hub.Replay(ctx, tt.userID, tt.after)
+			if err != nil {
+				t.Fatalf("Replay() error = %v", err)
+			}
+			var got []uint64
+			for _, e := range events {
+				if e.UserID != tt.userID {
+					t.Errorf("Replay(%q) returned an event of %q", tt.userID, e.UserID)
+				}
+				got = append(got, e.ID)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Replay(%q, %d) = %v, want %v", tt.userID, tt.after, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPublishSendsToSubscribers(t *testing.T) {
+	ctx := context.Background()
+	hub := NewHub(storage.NewMemory(), 10, time.Minute)
+	alice := hub.Subscribe("alice")
+	defer alice.Close()
+	bob := hub.Subscribe("bob")
+	defer bob.Close()
+
+	e, sent, err := hub.Publish(ctx, Event{UserID: "alice", Title: "Driver arriving"})
+	if err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+	if sent != 1 {
+		t.Errorf("Publish() sent = %d, want 1", sent)
+	}
+	if got := <-alice.Events(); got.ID != e.ID || got.Title != "Driver arriving" {
+		t.Errorf("alice received %+v, want %+v", got, e)
+	}
+	select {
+	case got := <-bob.Events():
+		t.Errorf("bob received %+v", got)
+	default:
+	}
+
+	hub.Close()
+	if _, ok := <-alice.Events(); ok {
+		t.Error("Events() still open after Close")
+	}
+}
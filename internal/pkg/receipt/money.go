@@ -0,0 +1,92 @@
+package receipt
+
+import (
+	"strconv"
+	"strings"
+)
+
+// currencyFormat describes how amounts in a currency are written
+type currencyFormat struct {
+	symbol string
+	// decimals is the number of minor unit digits, e.g. 2 for cents
+	decimals    int
+	symbolAfter bool
+	thousands   string
+	decimal     string
+}
+
+// currencies are the currencies the platform charges in. Others fall back
+// to two decimals and the ISO code.
+var currencies = map[string]currencyFormat{
+	"USD": {symbol: "$", decimals: 2, thousands: ",", decimal: "."},
+	"EUR": {symbol: "€", decimals: 2, thousands: ",", decimal: "."},
+	"GBP": {symbol: "£", decimals: 2, thousands: ",", decimal: "."},
+	"SGD": {symbol: "S$", decimals: 2, thousands: ",", decimal: "."},
+	"INR": {symbol: "₹", decimals: 2, thousands: ",", decimal: "."},
+	"THB": {symbol: "฿", decimals: 2, thousands: ",", decimal: "."},
+	"JPY": {symbol: "¥", decimals: 0, thousands: ",", decimal: "."},
+	"KRW": {symbol: "₩", decimals: 0, thousands: ",", decimal: "."},
+	"IDR": {symbol: "Rp", decimals: 0, thousands: ".", decimal: ","},
+	"VND": {symbol: "₫", decimals: 0, symbolAfter: true, thousands: ".", decimal: ","},
+	"NPR": {symbol: "Rs", decimals: 2, thousands: ",", decimal: "."},
+	"KWD": {symbol: "KD", decimals: 3, thousands: ",", decimal: "."},
+}
+
+func formatOf(currency string) currencyFormat {
+	if f, ok := currencies[strings.ToUpper(currency)]; ok {
+		return f
+	}
+	return currencyFormat{symbol: strings.ToUpper(currency) + " ", decimals: 2, thousands: ",", decimal: "."}
+}
+
+// FormatMoney writes an amount given in minor units, e.g. 1240 USD as
+// "$12.40" and 125000 VND as "125.000 ₫"
+func FormatMoney(amount int64, currency string) string {
+	f := formatOf(currency)
+	number := formatNumber(amount, f)
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+	}
+	if f.symbolAfter {
+		return sign + number + " " + f.symbol
+	}
+	return sign + f.symbol + number
+}
+
+// FormatMoneyCode writes an amount with the ISO currency code instead of a
+// symbol, e.g. "12.40 USD", for output that cannot show every symbol
+func FormatMoneyCode(amount int64, currency string) string {
+	f := formatOf(currency)
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+	}
+	return sign + formatNumber(amount, f) + " " + strings.ToUpper(currency)
+}
+
+// formatNumber writes the absolute value of amount with grouping and the
+// currency's minor unit digits
+func formatNumber(amount int64, f currencyFormat) string {
+	if amount < 0 {
+		amount = -amount
+	}
+	digits := strconv.FormatInt(amount, 10)
+	if len(digits) <= f.decimals {
+		digits = strings.Repeat("0", f.decimals-len(digits)+1) + digits
+	}
+	whole, fraction := digits[:len(digits)-f.decimals], digits[len(digits)-f.decimals:]
+
+	var b strings.Builder
+	for i, d := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			b.WriteString(f.thousands)
+		}
+		b.WriteRune(d)
+	}
+	if f.decimals > 0 {
+		b.WriteString(f.decimal)
+		b.WriteString(fraction)
+	}
+	return b.String()
+}
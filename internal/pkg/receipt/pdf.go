@@ -0,0 +1,67 @@
+package receipt
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/go-pdf/fpdf"
+)
+
+// PDF renders the receipt as a one-page A4 document. The built-in fonts
+// only cover Western European text, so amounts use ISO currency codes and
+// other characters may be replaced.
+func (r *Receipt) PDF() ([]byte, error) {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+	pdf.SetTitle("Trip receipt "+r.TripID, true)
+	pdf.SetMargins(20, 20, 20)
+	pdf.AddPage()
+
+	pdf.SetFont("Helvetica", "B", 18)
+	pdf.CellFormat(0, 10, "Trip receipt", "", 1, "L", false, 0, "")
+	pdf.SetFont("Helvetica", "", 10)
+	pdf.SetTextColor(100, 100, 100)
+	meta := "Trip " + r.TripID
+	if !r.CompletedAt.IsZero() {
+		meta += "  |  " + r.CompletedAt.Format("Jan 2, 2006 15:04 MST")
+	}
+	pdf.CellFormat(0, 6, tr(meta), "", 1, "L", false, 0, "")
+	pdf.Ln(4)
+
+	pdf.SetTextColor(0, 0, 0)
+	details := [][2]string{
+		{"Rider", r.RiderName},
+		{"Pickup", r.Pickup},
+		{"Drop-off", r.Dropoff},
+		{"Distance", r.Distance()},
+		{"Duration", r.Duration()},
+		{"Payment", r.PaymentMethod.String()},
+	}
+	for _, row := range details {
+		if row[1] == "" {
+			continue
+		}
+		pdf.SetFont("Helvetica", "B", 10)
+		pdf.CellFormat(30, 7, row[0], "", 0, "L", false, 0, "")
+		pdf.SetFont("Helvetica", "", 10)
+		pdf.MultiCell(0, 7, tr(row[1]), "", "L", false)
+	}
+	pdf.Ln(4)
+
+	pdf.SetFont("Helvetica", "B", 12)
+	pdf.CellFormat(0, 8, "Fare breakdown", "B", 1, "L", false, 0, "")
+	pdf.SetFont("Helvetica", "", 10)
+	for _, line := range r.Lines() {
+		pdf.CellFormat(120, 7, line.Label, "", 0, "L", false, 0, "")
+		pdf.CellFormat(0, 7, FormatMoneyCode(line.Amount, r.Currency), "", 1, "R", false, 0, "")
+	}
+	pdf.SetFont("Helvetica", "B", 11)
+	pdf.CellFormat(120, 9, "Total", "T", 0, "L", false, 0, "")
+	pdf.CellFormat(0, 9, FormatMoneyCode(r.Fare.Total(), r.Currency), "T", 1, "R", false, 0, "")
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, fmt.Errorf("render receipt pdf: %w", err)
+	}
+	return buf.Bytes(), nil
+}
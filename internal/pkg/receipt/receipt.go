@@ -0,0 +1,193 @@
+package receipt
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrInvalid is returned for receipt data that cannot be rendered
+var ErrInvalid = errors.New("invalid receipt")
+
+// Fare holds the fare components in minor units of the trip's currency.
+// Discounts are given as a positive amount and subtracted.
+type Fare struct {
+	Base      int64 `json:"base"`
+	Distance  int64 `json:"distance"`
+	Time      int64 `json:"time"`
+	Surge     int64 `json:"surge"`
+	Tolls     int64 `json:"tolls"`
+	Tips      int64 `json:"tips"`
+	Discounts int64 `json:"discounts"`
+	Taxes     int64 `json:"taxes"`
+}
+
+// Total is the amount charged
+func (f Fare) Total() int64 {
+	return f.Base + f.Distance + f.Time + f.Surge + f.Tolls + f.Tips - f.Discounts + f.Taxes
+}
+
+// PaymentMethod is how the rider paid, e.g. a card brand and last digits
+type PaymentMethod struct {
+	Type  string `json:"type"`
+	Brand string `json:"brand,omitempty"`
+	Last4 string `json:"last4,omitempty"`
+}
+
+func (p PaymentMethod) String() string {
+	switch {
+	case p.Brand != "" && p.Last4 != "":
+		return p.Brand + " •••• " + p.Last4
+	case p.Brand != "":
+		return p.Brand
+	case p.Type != "":
+		return strings.ToUpper(p.Type[:1]) + p.Type[1:]
+	}
+	return "Unknown"
+}
+
+// Receipt is a completed trip as described by the ride service's
+// ride.trip_completed event
+type Receipt struct {
+	TripID          string        `json:"trip_id"`
+	RiderName       string        `json:"rider_name"`
+	Currency        string        `json:"currency"`
+	Fare            Fare          `json:"fare"`
+	Pickup          string        `json:"pickup"`
+	Dropoff         string        `json:"dropoff"`
+	StartedAt       time.Time     `json:"started_at"`
+	CompletedAt     time.Time     `json:"completed_at"`
+	DistanceMeters  int64         `json:"distance_meters"`
+	DurationSeconds int64         `json:"duration_seconds"`
+	PaymentMethod   PaymentMethod `json:"payment_method"`
+	// Total is optional; when present it must match the fare components
+	Total *int64 `json:"total,omitempty"`
+}
+
+// FromEvent decodes the "receipt" object of a trip-completed event and
+// checks it adds up
+func FromEvent(raw interface{}) (*Receipt, error) {
+	encoded, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalid, err)
+	}
+	r := &Receipt{}
+	if err := json.Unmarshal(encoded, r); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalid, err)
+	}
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Validate reports missing fields and totals that do not match
+func (r *Receipt) Validate() error {
+	var problems []string
+	if r.TripID == "" {
+		problems = append(problems, "trip_id is required")
+	}
+	if len(r.Currency) != 3 {
+		problems = append(problems, "currency must be an ISO 4217 code")
+	}
+	if r.Pickup == "" || r.Dropoff == "" {
+		problems = append(problems, "pickup and dropoff are required")
+	}
+	if r.Fare.Total() < 0 {
+		problems = append(problems, "fare total must not be negative")
+	}
+	if r.Total != nil && *r.Total != r.Fare.Total() {
+		problems = append(problems, fmt.Sprintf("total %d does not match fare components %d", *r.Total, r.Fare.Total()))
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("%w: %s", ErrInvalid, strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// Line is one row of the fare breakdown
+type Line struct {
+	Label  string
+	Amount int64
+}
+
+// Lines lists the non-zero fare components in display order
+func (r *Receipt) Lines() []Line {
+	all := []Line{
+		{"Base fare", r.Fare.Base},
+		{"Distance", r.Fare.Distance},
+		{"Time", r.Fare.Time},
+		{"Surge", r.Fare.Surge},
+		{"Tolls", r.Fare.Tolls},
+		{"Tip", r.Fare.Tips},
+		{"Discounts", -r.Fare.Discounts},
+		{"Taxes", r.Fare.Taxes},
+	}
+	lines := make([]Line, 0, len(all))
+	for _, line := range all {
+		if line.Amount != 0 || line.Label == "Base fare" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// Distance is the trip length for display, e.g. "8.2 km"
+func (r *Receipt) Distance() string {
+	if r.DistanceMeters < 1000 {
+		return fmt.Sprintf("%d m", r.DistanceMeters)
+	}
+	return fmt.Sprintf("%.1f km", float64(r.DistanceMeters)/1000)
+}
+
+// Duration is the trip time for display, e.g. "21 min"
+func (r *Receipt) Duration() string {
+	d := time.Duration(r.DurationSeconds) * time.Second
+	if d >= time.Hour {
+		return fmt.Sprintf("%d h %d min", int(d.Hours()), int(d.Minutes())%60)
+	}
+	return fmt.Sprintf("%d min", int(d.Round(time.Minute).Minutes()))
+}
+
+// View is the data the HTML template renders, with amounts already
+// formatted for the currency
+type View struct {
+	TripID        string
+	RiderName     string
+	Pickup        string
+	Dropoff       string
+	Date          string
+	Distance      string
+	Duration      string
+	PaymentMethod string
+	Lines         []ViewLine
+	Total         string
+}
+
+type ViewLine struct {
+	Label  string
+	Amount string
+}
+
+// View formats the receipt for the email template
+func (r *Receipt) View() View {
+	v := View{
+		TripID:        r.TripID,
+		RiderName:     r.RiderName,
+		Pickup:        r.Pickup,
+		Dropoff:       r.Dropoff,
+		Distance:      r.Distance(),
+		Duration:      r.Duration(),
+		PaymentMethod: r.PaymentMethod.String(),
+		Total:         FormatMoney(r.Fare.Total(), r.Currency),
+	}
+	if !r.CompletedAt.IsZero() {
+		v.Date = r.CompletedAt.Format("Jan 2, 2006 15:04 MST")
+	}
+	for _, line := range r.Lines() {
+		v.Lines = append(v.Lines, ViewLine{Label: line.Label, Amount: FormatMoney(line.Amount, r.Currency)})
+	}
+	return v
+}
@@ -0,0 +1,136 @@
+package receipt
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"ride-sharing-notification/internal/pkg/email"
+	"ride-sharing-notification/internal/pkg/logging"
+	"ride-sharing-notification/internal/pkg/preferences"
+	"ride-sharing-notification/internal/pkg/storage"
+
+	"go.uber.org/zap"
+)
+
+// sentBucket remembers the trips whose receipt went out, so a redelivered
+// event does not email it again
+const sentBucket = "receipts_sent"
+
+// sentRetention is how long sent receipts are remembered, well beyond the
+// time an event may take to be redelivered
+const sentRetention = 7 * 24 * time.Hour
+
+var (
+	// ErrNoAddress is returned when neither the event nor the rider's
+	// preferences give an email address
+	ErrNoAddress = errors.New("no email address for receipt")
+	// ErrAlreadySent is returned for trips whose receipt was sent before
+	ErrAlreadySent = errors.New("receipt already sent")
+)
+
+// Sender emails receipts through the email service's template pipeline
+type Sender struct {
+	email       *email.Service
+	preferences *preferences.Service
+	kv          storage.KV
+	now         func() time.Time
+}
+
+func NewSender(emailSvc *email.Service, preferencesSvc *preferences.Service, kv storage.KV) *Sender {
+	return &Sender{
+		email:       emailSvc,
+		preferences: preferencesSvc,
+		kv:          kv,
+		now:         time.Now,
+	}
+}
+
+// Send emails r with a PDF copy attached, once per trip. to may be empty,
+// in which case the first address in the rider's preferences is used.
+func (s *Sender) Send(ctx context.Context, userID, to string, r *Receipt) error {
+	if _, err := s.kv.Get(ctx, sentBucket, r.TripID); err == nil {
+		return ErrAlreadySent
+	} else if !errors.Is(err, storage.ErrNotFound) {
+		return err
+	}
+	if to == "" && userID != "" {
+		prefs, err := s.preferences.Get(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if len(prefs.Emails) > 0 {
+			to = prefs.Emails[0]
+		}
+	}
+	if to == "" {
+		return ErrNoAddress
+	}
+
+	pdf, err := r.PDF()
+	if err != nil {
+		return err
+	}
+	_, err = s.email.VerifyEmail(ctx, &email.EmailPayload{
+		To:         to,
+		EMAIL_TYPE: email.EmailTypeTripReceipt,
+		Data: map[string]interface{}{
+			"receipt": r.View(),
+		},
+		Attachments: []email.Attachment{{
+			Filename:    fmt.Sprintf("receipt-%s.pdf", r.TripID),
+			ContentType: "application/pdf",
+			Content:     pdf,
+		}},
+	})
+	if err != nil {
+		return err
+	}
+	// The email is out either way; failing to remember it only risks a
+	// duplicate on redelivery
+	sentAt := []byte(strconv.FormatInt(s.now().Unix(), 10))
+	if err := s.kv.Put(context.WithoutCancel(ctx), sentBucket, r.TripID, sentAt); err != nil {
+		logging.GetLogger().WithContext(ctx).Warn("failed to record sent receipt",
+			zap.String("trip_id", r.TripID), zap.Error(err))
+	}
+	return nil
+}
+
+// Run forgets receipts sent longer than the retention ago every interval
+// until ctx is done
+func (s *Sender) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.expire(ctx); err != nil {
+				logging.GetLogger().Error("failed to expire sent receipts", zap.Error(err))
+			}
+		}
+	}
+}
+
+func (s *Sender) expire(ctx context.Context) error {
+	cutoff := s.now().Add(-sentRetention).Unix()
+	var stale []string
+	err := s.kv.Scan(ctx, sentBucket, "", func(key string, value []byte) error {
+		if sentAt, err := strconv.ParseInt(string(value), 10, 64); err != nil || sentAt < cutoff {
+			stale = append(stale, key)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for _, key := range stale {
+		if err := s.kv.Delete(ctx, sentBucket, key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,186 @@
+package reload
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"ride-sharing-notification/config"
+	"ride-sharing-notification/internal/pkg/logging"
+	"ride-sharing-notification/internal/pkg/metrics"
+
+	"go.uber.org/zap"
+)
+
+const (
+	resultApplied   = "applied"
+	resultUnchanged = "unchanged"
+	resultFailed    = "failed"
+)
+
+// ApplyFunc pushes the reloadable part of cfg into a running component. It
+// cannot fail: values are validated while loading, before any component is
+// updated, so a reload applies everywhere or nowhere.
+type ApplyFunc func(cfg *config.Config)
+
+// Status describes the configuration currently in effect
+type Status struct {
+	Generation int64     `json:"generation"`
+	AppliedAt  time.Time `json:"applied_at"`
+	// Pending lists changed fields that only take effect after a restart
+	Pending   []string  `json:"pending,omitempty"`
+	LastError string    `json:"last_error,omitempty"`
+	LastCheck time.Time `json:"last_check"`
+}
+
+// Manager reloads the configuration on demand, on SIGHUP or when the YAML
+// file changes, and hands the result to every registered component
+type Manager struct {
+	load func() (*config.Config, error)
+
+	mu       sync.Mutex // serialises reloads
+	current  atomic.Pointer[config.Config]
+	appliers []namedApply
+	status   Status
+}
+
+type namedApply struct {
+	name  string
+	apply ApplyFunc
+}
+
+func NewManager(cfg *config.Config, load func() (*config.Config, error)) *Manager {
+	m := &Manager{load: load}
+	m.current.Store(cfg)
+	m.status = Status{AppliedAt: time.Now(), LastCheck: time.Now()}
+	return m
+}
+
+// Register adds a component to be updated on every successful reload
+func (m *Manager) Register(name string, apply ApplyFunc) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.appliers = append(m.appliers, namedApply{name: name, apply: apply})
+}
+
+// Current returns the configuration in effect
+func (m *Manager) Current() *config.Config {
+	return m.current.Load()
+}
+
+// Status returns details about the last reload
+func (m *Manager) Status() Status {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.status
+}
+
+// Reload loads the configuration again and applies the reloadable fields
+// that changed. The running configuration is kept when loading or
+// validation fails.
+func (m *Manager) Reload() ([]config.Change, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	logger := logging.GetLogger()
+	m.status.LastCheck = time.Now()
+
+	// load validates the result the same way as at startup
+	next, err := m.load()
+	if err != nil {
+		metrics.ConfigReloadsTotal.WithLabelValues(resultFailed).Inc()
+		m.status.LastError = err.Error()
+		logger.Error("configuration reload rejected", zap.Error(err))
+		return nil, fmt.Errorf("reload configuration: %w", err)
+	}
+	m.status.LastError = ""
+
+	current := m.current.Load()
+	changes := current.Diff(next)
+
+	var applied, pending []string
+	for _, change := range changes {
+		if change.Reloadable {
+			applied = append(applied, change.Field)
+		} else {
+			pending = append(pending, change.Field)
+		}
+	}
+	m.status.Pending = pending
+	if len(pending) > 0 {
+		logger.Warn("configuration changes require a restart", zap.Strings("fields", pending))
+	}
+	if len(applied) == 0 {
+		metrics.ConfigReloadsTotal.WithLabelValues(resultUnchanged).Inc()
+		return changes, nil
+	}
+
+	m.status.Generation++
+	// Logged before applying so a raised log level cannot hide the change
+	logger.Info("reloading configuration",
+		zap.Strings("fields", applied),
+		zap.Int64("generation", m.status.Generation),
+	)
+
+	merged := current.Reloaded(next)
+	for _, a := range m.appliers {
+		a.apply(merged)
+		logger.Debug("applied configuration", zap.String("component", a.name))
+	}
+	m.current.Store(merged)
+
+	m.status.AppliedAt = time.Now()
+	metrics.ConfigReloadsTotal.WithLabelValues(resultApplied).Inc()
+	metrics.ConfigLastReloadTimestamp.SetToCurrentTime()
+	return changes, nil
+}
+
+// Watch reloads on SIGHUP and whenever file's modification time changes,
+// checking every interval, until ctx is done. A non-positive interval
+// disables polling.
+func (m *Manager) Watch(ctx context.Context, file string, interval time.Duration) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	var tick <-chan time.Time
+	if interval > 0 {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+	modTime := fileModTime(file)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hup:
+			logging.GetLogger().Info("received SIGHUP, reloading configuration")
+			_, _ = m.Reload()
+		case <-tick:
+			mod := fileModTime(file)
+			if mod.Equal(modTime) {
+				continue
+			}
+			modTime = mod
+			logging.GetLogger().Info("configuration file changed, reloading", zap.String("file", file))
+			_, _ = m.Reload()
+		}
+	}
+}
+
+// fileModTime returns the zero time for missing files so creating or
+// deleting the file also counts as a change
+func fileModTime(file string) time.Time {
+	info, err := os.Stat(file)
+	if err != nil {
+		return time.Time{}
+	}
+	return info.ModTime()
+}
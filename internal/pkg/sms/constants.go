@@ -0,0 +1,104 @@
+package sms
+
+import "text/template"
+
+const (
+	SMSTypeRegister       = "USER_REGISTER"
+	SMSTypeForgetPassword = "FORGET_PASSWORD"
+	SMSTypeDriverArriving = "DRIVER_ARRIVING"
+
+	// Ride lifecycle
+	SMSTypeRideRequested  = "RIDE_REQUESTED"
+	SMSTypeDriverAssigned = "DRIVER_ASSIGNED"
+	SMSTypeTripStarted    = "TRIP_STARTED"
+	SMSTypeTripCompleted  = "TRIP_COMPLETED"
+	SMSTypeRideCancelled  = "RIDE_CANCELLED"
+	SMSTypePaymentFailed  = "PAYMENT_FAILED"
+
+	// Driver account
+	SMSTypeDriverPayout     = "DRIVER_PAYOUT"
+	SMSTypeDriverRating     = "DRIVER_RATING"
+	SMSTypeDocumentReminder = "DOCUMENT_REMINDER"
+
+	// SMSTypeDigest combines notifications batched over a window
+	SMSTypeDigest = "DIGEST"
+)
+
+// Payload is an SMS to send. Body is sent as-is when set; otherwise the
+// template registered for Type is rendered with Data.
+type Payload struct {
+	To   string
+	Type string
+	Body string
+	Data map[string]interface{}
+}
+
+type SMSTemplate struct {
+	Body           string
+	RequiredFields []string
+}
+
+var SMSTemplates = map[string]SMSTemplate{
+	SMSTypeRegister: {
+		Body:           "Your Ride Sharing verification code is {{.otp}}. It expires in 5 minutes.",
+		RequiredFields: []string{"otp"},
+	},
+	SMSTypeForgetPassword: {
+		Body:           "Your Ride Sharing password reset code is {{.otp}}. If you did not request it, ignore this message.",
+		RequiredFields: []string{"otp"},
+	},
+	SMSTypeDriverArriving: {
+		Body:           "{{.driver_name}} is arriving in {{.eta_minutes}} min in a {{.vehicle}} ({{.plate}}).",
+		RequiredFields: []string{"driver_name", "eta_minutes", "vehicle", "plate"},
+	},
+	SMSTypeRideRequested: {
+		Body:           "We're finding a driver for your ride to {{.dropoff}}.",
+		RequiredFields: []string{"pickup", "dropoff"},
+	},
+	SMSTypeDriverAssigned: {
+		Body:           "{{.driver_name}} is on the way in a {{.vehicle}} ({{.plate}}), about {{.eta_minutes}} min away.",
+		RequiredFields: []string{"driver_name", "vehicle", "plate", "eta_minutes"},
+	},
+	SMSTypeTripStarted: {
+		Body:           "Your trip with {{.driver_name}} to {{.dropoff}} has started.",
+		RequiredFields: []string{"driver_name", "dropoff"},
+	},
+	SMSTypeTripCompleted: {
+		Body:           "You've arrived at {{.dropoff}}. Fare: {{.fare}}. Thanks for riding with us!",
+		RequiredFields: []string{"fare", "dropoff"},
+	},
+	// fee is optional; it is only mentioned when present
+	SMSTypeRideCancelled: {
+		Body:           "Your ride was cancelled by {{.cancelled_by}}.{{with index . \"fee\"}} A cancellation fee of {{.}} was charged.{{end}}",
+		RequiredFields: []string{"cancelled_by"},
+	},
+	SMSTypePaymentFailed: {
+		Body:           "We couldn't charge {{.amount}} for your ride. Please update your payment method in the app.",
+		RequiredFields: []string{"amount"},
+	},
+	SMSTypeDriverPayout: {
+		Body:           "Your payout of {{.amount}} is on its way to your bank account.",
+		RequiredFields: []string{"amount"},
+	},
+	SMSTypeDriverRating: {
+		Body:           "A rider rated your trip {{.rating}} stars.",
+		RequiredFields: []string{"rating"},
+	},
+	SMSTypeDocumentReminder: {
+		Body:           "Your {{.document}} expires on {{.expires_on}}. Upload a new one in the app to keep driving.",
+		RequiredFields: []string{"document", "expires_on"},
+	},
+	SMSTypeDigest: {
+		Body:           "You have {{.count}} Ride Sharing updates: {{.summary}}",
+		RequiredFields: []string{"count", "summary"},
+	},
+}
+
+// parsedTemplates are compiled once; templates are short enough to keep in code
+var parsedTemplates = func() map[string]*template.Template {
+	parsed := make(map[string]*template.Template, len(SMSTemplates))
+	for name, t := range SMSTemplates {
+		parsed[name] = template.Must(template.New(name).Option("missingkey=error").Parse(t.Body))
+	}
+	return parsed
+}()
@@ -0,0 +1,26 @@
+package sms
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+// ErrInvalidNumber is returned for recipients that are not E.164 numbers
+var ErrInvalidNumber = errors.New("phone number must be in E.164 format, e.g. +14155552671")
+
+var (
+	e164Pattern = regexp.MustCompile(`^\+[1-9][0-9]{6,14}$`)
+	// Separators people commonly type inside phone numbers
+	numberSeparators = strings.NewReplacer(" ", "", "-", "", "(", "", ")", "", ".", "")
+)
+
+// NormalizeE164 strips common separators and checks that number is a
+// "+<country code><subscriber>" number of at most 15 digits
+func NormalizeE164(number string) (string, error) {
+	normalized := numberSeparators.Replace(strings.TrimSpace(number))
+	if !e164Pattern.MatchString(normalized) {
+		return "", ErrInvalidNumber
+	}
+	return normalized, nil
+}
@@ -0,0 +1,45 @@
+package sms
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNormalizeE164(t *testing.T) {
+	tests := []struct {
+		number  string
+		want    string
+		wantErr bool
+	}{
+		{number: "+14155552671", want: "+14155552671"},
+		{number: "+1 (415) 555-2671", want: "+14155552671"},
+		{number: "  +44 20.7946.0000 ", want: "+442079460000"},
+		{number: "+1234567", want: "+1234567"},
+		{number: "+123456789012345", want: "+123456789012345"},
+		{number: "", wantErr: true},
+		{number: "14155552671", wantErr: true},
+		{number: "+04155552671", wantErr: true},
+		{number: "+123456", wantErr: true},
+		{number: "+1234567890123456", wantErr: true},
+		{number: "+1415555abcd", wantErr: true},
+		{number: "+1/415/555/2671", wantErr: true},
+		{number: "++14155552671", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.number, func(t *testing.T) {
+			got, err := NormalizeE164(tt.number)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidNumber) {
+					t.Errorf("NormalizeE164(%q) error = %v, want %v", tt.number, err, ErrInvalidNumber)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NormalizeE164(%q) error = %v", tt.number, err)
+			}
+			if got != tt.want {
+				t.Errorf("NormalizeE164(%q) = %q, want %q", tt.number, got, tt.want)
+			}
+		})
+	}
+}
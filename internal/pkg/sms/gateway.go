@@ -0,0 +1,98 @@
+package sms
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// Gateway posts messages as JSON to a generic HTTP SMS gateway:
+//
+//	{"to": "+14155552671", "from": "RideShare", "body": "..."}
+//
+// Any 2xx response is a success; an "id" or "message_id" field in the
+// response body is used as the provider message ID.
+type Gateway struct {
+	url        string
+	apiKey     string
+	authHeader string
+	from       string
+	client     *http.Client
+}
+
+func NewGateway(url, apiKey, authHeader, from string, timeout time.Duration) *Gateway {
+	return &Gateway{
+		url:        url,
+		apiKey:     apiKey,
+		authHeader: authHeader,
+		from:       from,
+		client:     &http.Client{Timeout: timeout},
+	}
+}
+
+func (g *Gateway) Name() string {
+	return "http"
+}
+
+type gatewayRequest struct {
+	To   string `json:"to"`
+	From string `json:"from,omitempty"`
+	Body string `json:"body"`
+}
+
+type gatewayResponse struct {
+	ID        string `json:"id"`
+	MessageID string `json:"message_id"`
+	Error     string `json:"error"`
+}
+
+func (g *Gateway) Send(ctx context.Context, to, body string) (string, error) {
+	payload, err := json.Marshal(gatewayRequest{To: to, From: g.from, Body: body})
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, g.url, bytes.NewReader(payload))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if g.apiKey != "" {
+		value := g.apiKey
+		if g.authHeader == "Authorization" {
+			value = "Bearer " + g.apiKey
+		}
+		req.Header.Set(g.authHeader, value)
+	}
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return "", &ProviderError{Provider: g.Name(), Message: err.Error()}
+	}
+	defer resp.Body.Close()
+
+	var parsed gatewayResponse
+	raw, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	_ = json.Unmarshal(raw, &parsed)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		message := parsed.Error
+		if message == "" {
+			message = fmt.Sprintf("gateway returned %s", resp.Status)
+		}
+		return "", &ProviderError{
+			Provider:   g.Name(),
+			StatusCode: resp.StatusCode,
+			Message:    message,
+		}
+	}
+
+	if parsed.ID != "" {
+		return parsed.ID, nil
+	}
+	return parsed.MessageID, nil
+}
@@ -0,0 +1,99 @@
+package sms
+
+import "unicode/utf16"
+
+// Encoding is the character set a message is sent in
+type Encoding string
+
+const (
+	EncodingGSM7 Encoding = "GSM-7"
+	EncodingUCS2 Encoding = "UCS-2"
+)
+
+// Per-segment capacity in encoding units. Concatenated messages lose room
+// to the user data header.
+const (
+	gsm7Single    = 160
+	gsm7Multipart = 153
+	ucs2Single    = 70
+	ucs2Multipart = 67
+)
+
+const (
+	// GSM 03.38 basic character set
+	gsm7Basic = "@£$¥èéùìòÇ\nØø\rÅåΔ_ΦΓΛΩΠΨΣΘΞÆæßÉ !\"#¤%&'()*+,-./0123456789:;<=>?" +
+		"¡ABCDEFGHIJKLMNOPQRSTUVWXYZÄÖÑÜ§¿abcdefghijklmnopqrstuvwxyzäöñüà"
+	// Extension table characters, each sent as an escape plus the character
+	gsm7Extended = "\f^{}\\[~]|€"
+)
+
+var gsm7Width = func() map[rune]int {
+	width := make(map[rune]int, len(gsm7Basic)+len(gsm7Extended))
+	for _, r := range gsm7Basic {
+		width[r] = 1
+	}
+	for _, r := range gsm7Extended {
+		width[r] = 2
+	}
+	return width
+}()
+
+// SegmentInfo describes how a message is split for delivery
+type SegmentInfo struct {
+	Encoding Encoding
+	// Units is the length in septets (GSM-7) or UTF-16 code units (UCS-2)
+	Units    int
+	Segments int
+}
+
+// CountSegments reports the encoding and number of segments text needs.
+// Text containing any character outside GSM-7 is sent as UCS-2.
+func CountSegments(text string) SegmentInfo {
+	if text == "" {
+		return SegmentInfo{Encoding: EncodingGSM7}
+	}
+
+	widths := make([]int, 0, len(text))
+	encoding := EncodingGSM7
+	for _, r := range text {
+		w, ok := gsm7Width[r]
+		if !ok {
+			encoding = EncodingUCS2
+			break
+		}
+		widths = append(widths, w)
+	}
+
+	single, multipart := gsm7Single, gsm7Multipart
+	if encoding == EncodingUCS2 {
+		single, multipart = ucs2Single, ucs2Multipart
+		widths = widths[:0]
+		for _, r := range text {
+			// Characters outside the BMP take a surrogate pair
+			w := 1
+			if utf16.IsSurrogate(r) || r > 0xFFFF {
+				w = 2
+			}
+			widths = append(widths, w)
+		}
+	}
+
+	units := 0
+	for _, w := range widths {
+		units += w
+	}
+	if units <= single {
+		return SegmentInfo{Encoding: encoding, Units: units, Segments: 1}
+	}
+
+	// Escape sequences and surrogate pairs are never split across segments
+	segments, used := 1, 0
+	for _, w := range widths {
+		if used+w > multipart {
+			segments++
+			used = 0
+		}
+		used += w
+	}
+	return SegmentInfo{Encoding: encoding, Units: units, Segments: segments}
+}
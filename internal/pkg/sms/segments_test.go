@@ -0,0 +1,41 @@
+package sms
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCountSegments(t *testing.T) {
+	repeat, zhe := strings.Repeat, "Ж"
+	tests := []struct {
+		name string
+		text string
+		want SegmentInfo
+	}{
+		{"empty", "", SegmentInfo{Encoding: EncodingGSM7}},
+		{"short", "Your driver is arriving", SegmentInfo{EncodingGSM7, 23, 1}},
+		{"GSM-7 accents", "Café à Zürich", SegmentInfo{EncodingGSM7, 13, 1}},
+		{"GSM-7 single limit", repeat("a", 160), SegmentInfo{EncodingGSM7, 160, 1}},
+		{"GSM-7 multipart", repeat("a", 161), SegmentInfo{EncodingGSM7, 161, 2}},
+		{"GSM-7 two full parts", repeat("a", 306), SegmentInfo{EncodingGSM7, 306, 2}},
+		{"GSM-7 three parts", repeat("a", 307), SegmentInfo{EncodingGSM7, 307, 3}},
+		{"extension characters count twice", "€{}", SegmentInfo{EncodingGSM7, 6, 1}},
+		{"extension characters at single limit", repeat("€", 80), SegmentInfo{EncodingGSM7, 160, 1}},
+		{"escape not split across parts", repeat("a", 152) + "€" + repeat("a", 152), SegmentInfo{EncodingGSM7, 306, 3}},
+		{"UCS-2", "Привет", SegmentInfo{EncodingUCS2, 6, 1}},
+		{"one character forces UCS-2", repeat("a", 100) + zhe, SegmentInfo{EncodingUCS2, 101, 2}},
+		{"UCS-2 single limit", repeat(zhe, 70), SegmentInfo{EncodingUCS2, 70, 1}},
+		{"UCS-2 multipart", repeat(zhe, 71), SegmentInfo{EncodingUCS2, 71, 2}},
+		{"UCS-2 two full parts", repeat(zhe, 134), SegmentInfo{EncodingUCS2, 134, 2}},
+		{"UCS-2 three parts", repeat(zhe, 135), SegmentInfo{EncodingUCS2, 135, 3}},
+		{"emoji is a surrogate pair", "Rate your trip 👍", SegmentInfo{EncodingUCS2, 17, 1}},
+		{"surrogate pair not split across parts", repeat(zhe, 66) + "👍" + repeat(zhe, 66), SegmentInfo{EncodingUCS2, 134, 3}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CountSegments(tt.text); got != tt.want {
+				t.Errorf("CountSegments() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,322 @@
+package sms
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"sync/atomic"
+	"time"
+
+	"ride-sharing-notification/config"
+	"ride-sharing-notification/internal/pkg/metrics"
+	"ride-sharing-notification/internal/pkg/suppression"
+	"ride-sharing-notification/internal/pkg/throttle"
+	"ride-sharing-notification/internal/pkg/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const channelName = "sms"
+
+var (
+	// ErrDisabled is returned when the SMS channel is switched off
+	ErrDisabled = errors.New("sms channel is disabled")
+	// ErrUnknownType is returned for a payload without body whose type has no template
+	ErrUnknownType = errors.New("unknown sms type")
+	// ErrMissingField is returned when template data lacks a required field
+	ErrMissingField = errors.New("missing required field")
+	// ErrTooLong is returned when a message needs more segments than allowed
+	ErrTooLong = errors.New("sms exceeds the maximum number of segments")
+)
+
+// Provider delivers a single text message and returns the provider's
+// message ID
+type Provider interface {
+	Name() string
+	Send(ctx context.Context, to, body string) (string, error)
+}
+
+// ProviderError is a failure reported by a provider
+type ProviderError struct {
+	Provider   string
+	StatusCode int
+	Code       string
+	Message    string
+}
+
+func (e *ProviderError) Error() string {
+	if e.StatusCode == 0 {
+		return fmt.Sprintf("%s: %s", e.Provider, e.Message)
+	}
+	if e.Code != "" {
+		return fmt.Sprintf("%s: %d %s (code %s)", e.Provider, e.StatusCode, e.Message, e.Code)
+	}
+	return fmt.Sprintf("%s: %d %s", e.Provider, e.StatusCode, e.Message)
+}
+
+// Permanent reports whether the provider rejected the message itself, in
+// which case other providers would reject it too
+func (e *ProviderError) Permanent() bool {
+	return e.StatusCode == 400 || e.StatusCode == 422
+}
+
+// Result describes an accepted message
+type Result struct {
+	Provider  string
+	MessageID string
+	SegmentInfo
+}
+
+// ThrottleKey returns the throttle bucket used for provider
+func ThrottleKey(provider string) string {
+	return channelName + ":" + provider
+}
+
+type Service struct {
+	providers []Provider
+	throttler *throttle.Throttler
+	// suppressions are phone numbers that are never sent to
+	suppressions *suppression.List
+	settings     atomic.Pointer[settings]
+}
+
+// settings are the values that can change while the service is running
+type settings struct {
+	enabled     bool
+	maxSegments int
+	maxAttempts int
+	retryDelay  time.Duration
+	weights     map[string]int
+}
+
+func NewService(providers []Provider, throttler *throttle.Throttler, suppressions *suppression.List) *Service {
+	s := &Service{
+		providers:    providers,
+		throttler:    throttler,
+		suppressions: suppressions,
+	}
+	s.settings.Store(&settings{enabled: len(providers) > 0, maxSegments: 3, maxAttempts: 1})
+	return s
+}
+
+// NewFromConfig creates the configured providers. Twilio is used when an
+// account SID is set and the HTTP gateway when a URL is set.
+func NewFromConfig(cfg *config.Config, throttler *throttle.Throttler, suppressions *suppression.List) *Service {
+	var providers []Provider
+	if cfg.SMS.Twilio.AccountSID != "" {
+		providers = append(providers, NewTwilio(
+			cfg.SMS.Twilio.AccountSID,
+			cfg.SMS.Twilio.AuthToken,
+			cfg.SMS.From,
+			cfg.SMS.Twilio.MessagingServiceSID,
+			cfg.SMS.Twilio.BaseURL,
+			cfg.SMS.Timeout,
+		))
+	}
+	if cfg.SMS.HTTP.URL != "" {
+		providers = append(providers, NewGateway(
+			cfg.SMS.HTTP.URL,
+			cfg.SMS.HTTP.APIKey,
+			cfg.SMS.HTTP.AuthHeader,
+			cfg.SMS.From,
+			cfg.SMS.Timeout,
+		))
+	}
+
+	s := NewService(providers, throttler, suppressions)
+	s.ApplyConfig(cfg)
+	return s
+}
+
+// ApplyConfig updates the channel toggle, limits and provider weights for
+// sends that start afterwards
+func (s *Service) ApplyConfig(cfg *config.Config) {
+	maxAttempts := cfg.SMS.Retry.MaxAttempts
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+	s.settings.Store(&settings{
+		enabled:     cfg.SMS.Enabled && len(s.providers) > 0,
+		maxSegments: cfg.SMS.MaxSegments,
+		maxAttempts: maxAttempts,
+		retryDelay:  cfg.SMS.Retry.Delay,
+		weights:     cfg.SMS.Weights,
+	})
+}
+
+// Enabled reports whether SMS are currently being sent
+func (s *Service) Enabled() bool {
+	return s.settings.Load().enabled
+}
+
+// Send renders and delivers p. Providers are tried in weighted order; each
+// attempt falls back to the next provider when one fails.
+func (s *Service) Send(ctx context.Context, p *Payload) (result *Result, err error) {
+	start := time.Now()
+	ctx, span := tracing.Start(ctx, "sms.send",
+		trace.WithAttributes(attribute.String("notification.type", p.Type)),
+	)
+	defer func() {
+		tracing.RecordError(span, err)
+		span.End()
+
+		status := metrics.StatusSent
+		switch {
+		case errors.Is(err, ErrDisabled), errors.Is(err, suppression.ErrSuppressed):
+			status = metrics.StatusSkipped
+		case err != nil:
+			status = metrics.StatusFailed
+		}
+		metrics.SendsTotal.WithLabelValues(channelName, p.Type, status).Inc()
+		metrics.SendDuration.WithLabelValues(channelName, p.Type).Observe(time.Since(start).Seconds())
+	}()
+
+	current := s.settings.Load()
+	if !current.enabled {
+		return nil, ErrDisabled
+	}
+
+	to, err := NormalizeE164(p.To)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.suppressions.Check(ctx, suppression.KindPhone, to); err != nil {
+		return nil, err
+	}
+
+	body := p.Body
+	if body == "" {
+		if body, err = render(p.Type, p.Data); err != nil {
+			return nil, err
+		}
+	}
+
+	info := CountSegments(body)
+	span.SetAttributes(
+		attribute.String("sms.encoding", string(info.Encoding)),
+		attribute.Int("sms.segments", info.Segments),
+	)
+	if info.Segments > current.maxSegments {
+		return nil, fmt.Errorf("%w: %d segments, limit %d", ErrTooLong, info.Segments, current.maxSegments)
+	}
+
+	var lastErr error
+	for attempt := 1; attempt <= current.maxAttempts; attempt++ {
+		if attempt > 1 {
+			metrics.SendRetriesTotal.WithLabelValues(channelName, p.Type).Inc()
+			time.Sleep(current.retryDelay)
+		}
+
+		for _, provider := range s.order(current.weights) {
+			messageID, err := s.sendVia(ctx, provider, to, body)
+			if err == nil {
+				return &Result{
+					Provider:    provider.Name(),
+					MessageID:   messageID,
+					SegmentInfo: info,
+				}, nil
+			}
+			lastErr = err
+
+			var providerErr *ProviderError
+			if errors.As(err, &providerErr) && providerErr.Permanent() {
+				return nil, err
+			}
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("after %d attempts, last error: %w", current.maxAttempts, lastErr)
+}
+
+func (s *Service) sendVia(ctx context.Context, provider Provider, to, body string) (messageID string, err error) {
+	ctx, span := tracing.Start(ctx, "sms.provider_send",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("notification.channel", channelName),
+			attribute.String("provider", provider.Name()),
+		),
+	)
+	defer func() {
+		tracing.RecordError(span, err)
+		span.End()
+
+		result := "ok"
+		if err != nil {
+			result = "error"
+		}
+		metrics.ProviderRequestsTotal.WithLabelValues(channelName, provider.Name(), result).Inc()
+	}()
+
+	// Queue behind the provider's throughput limit
+	key := ThrottleKey(provider.Name())
+	waited, err := s.throttler.Wait(ctx, key)
+	metrics.ThrottleWait.WithLabelValues(key).Observe(waited.Seconds())
+	if err != nil {
+		return "", fmt.Errorf("waiting for send slot: %w", err)
+	}
+
+	return provider.Send(ctx, to, body)
+}
+
+// order returns the providers to try: one picked at random in proportion to
+// its weight, then the rest by descending weight. Providers without a
+// configured weight count as weight 1; weight 0 makes a provider fallback-only.
+func (s *Service) order(weights map[string]int) []Provider {
+	weightOf := func(p Provider) int {
+		if w, ok := weights[p.Name()]; ok {
+			return w
+		}
+		return 1
+	}
+
+	ordered := slices.Clone(s.providers)
+	slices.SortStableFunc(ordered, func(a, b Provider) int {
+		return cmp.Compare(weightOf(b), weightOf(a))
+	})
+
+	total := 0
+	for _, p := range ordered {
+		total += weightOf(p)
+	}
+	if total == 0 {
+		return ordered
+	}
+
+	pick := rand.IntN(total)
+	for i, p := range ordered {
+		if pick -= weightOf(p); pick < 0 {
+			// Move the chosen provider to the front, keeping the rest in order
+			copy(ordered[1:i+1], ordered[:i])
+			ordered[0] = p
+			break
+		}
+	}
+	return ordered
+}
+
+func render(smsType string, data map[string]interface{}) (string, error) {
+	tmpl, ok := parsedTemplates[smsType]
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrUnknownType, smsType)
+	}
+	for _, field := range SMSTemplates[smsType].RequiredFields {
+		if _, ok := data[field]; !ok {
+			return "", fmt.Errorf("%w %q for %s", ErrMissingField, field, smsType)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		metrics.TemplateRenderErrorsTotal.WithLabelValues(smsType).Inc()
+		return "", fmt.Errorf("failed to render template: %w", err)
+	}
+	return buf.String(), nil
+}
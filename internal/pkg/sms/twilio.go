@@ -0,0 +1,94 @@
+package sms
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Twilio sends through the Twilio Messages API or any gateway exposing the
+// same interface
+type Twilio struct {
+	accountSID          string
+	authToken           string
+	from                string
+	messagingServiceSID string
+	baseURL             string
+	client              *http.Client
+}
+
+func NewTwilio(accountSID, authToken, from, messagingServiceSID, baseURL string, timeout time.Duration) *Twilio {
+	return &Twilio{
+		accountSID:          accountSID,
+		authToken:           authToken,
+		from:                from,
+		messagingServiceSID: messagingServiceSID,
+		baseURL:             strings.TrimRight(baseURL, "/"),
+		client:              &http.Client{Timeout: timeout},
+	}
+}
+
+func (t *Twilio) Name() string {
+	return "twilio"
+}
+
+type twilioResponse struct {
+	SID     string `json:"sid"`
+	Status  string `json:"status"`
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (t *Twilio) Send(ctx context.Context, to, body string) (string, error) {
+	form := url.Values{}
+	form.Set("To", to)
+	form.Set("Body", body)
+	// A messaging service picks the sender itself
+	if t.messagingServiceSID != "" {
+		form.Set("MessagingServiceSid", t.messagingServiceSID)
+	} else {
+		form.Set("From", t.from)
+	}
+
+	endpoint := fmt.Sprintf("%s/2010-04-01/Accounts/%s/Messages.json", t.baseURL, url.PathEscape(t.accountSID))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(t.accountSID, t.authToken)
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return "", &ProviderError{Provider: t.Name(), Message: err.Error()}
+	}
+	defer resp.Body.Close()
+
+	var parsed twilioResponse
+	raw, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	_ = json.Unmarshal(raw, &parsed)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		message := parsed.Message
+		if message == "" {
+			message = http.StatusText(resp.StatusCode)
+		}
+		code := ""
+		if parsed.Code != 0 {
+			code = fmt.Sprint(parsed.Code)
+		}
+		return "", &ProviderError{
+			Provider:   t.Name(),
+			StatusCode: resp.StatusCode,
+			Code:       code,
+			Message:    message,
+		}
+	}
+	return parsed.SID, nil
+}
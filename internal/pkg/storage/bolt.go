@@ -0,0 +1,125 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// Bolt persists state in a single bbolt database file. The file is locked
+// by one process at a time, so replicas need separate files or a shared
+// KV implementation.
+type Bolt struct {
+	db *bolt.DB
+}
+
+func OpenBolt(path string) (*Bolt, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("create storage directory: %w", err)
+	}
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("open bolt database %s: %w", path, err)
+	}
+	return &Bolt{db: db}, nil
+}
+
+func (b *Bolt) Get(_ context.Context, bucket, key string) ([]byte, error) {
+	var value []byte
+	err := b.db.View(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket([]byte(bucket))
+		if bkt == nil {
+			return ErrNotFound
+		}
+		v := bkt.Get([]byte(key))
+		if v == nil {
+			return ErrNotFound
+		}
+		// Values are only valid inside the transaction
+		value = clone(v)
+		return nil
+	})
+	return value, err
+}
+
+func (b *Bolt) Put(_ context.Context, bucket, key string, value []byte) error {
+	return b.db.Update(func(tx *bolt.Tx) error {
+		bkt, err := tx.CreateBucketIfNotExists([]byte(bucket))
+		if err != nil {
+			return err
+		}
+		return bkt.Put([]byte(key), value)
+	})
+}
+
+func (b *Bolt) Delete(_ context.Context, bucket, key string) error {
+	return b.db.Update(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket([]byte(bucket))
+		if bkt == nil {
+			return nil
+		}
+		return bkt.Delete([]byte(key))
+	})
+}
+
+func (b *Bolt) Update(_ context.Context, bucket, key string, fn func(current []byte) ([]byte, error)) error {
+	return b.db.Update(func(tx *bolt.Tx) error {
+		bkt, err := tx.CreateBucketIfNotExists([]byte(bucket))
+		if err != nil {
+			return err
+		}
+
+		var current []byte
+		if v := bkt.Get([]byte(key)); v != nil {
+			current = clone(v)
+		}
+		next, err := fn(current)
+		if err != nil {
+			return err
+		}
+		if next == nil {
+			return bkt.Delete([]byte(key))
+		}
+		return bkt.Put([]byte(key), next)
+	})
+}
+
+func (b *Bolt) Scan(_ context.Context, bucket, prefix string, fn func(key string, value []byte) error) error {
+	// Collect first so fn may write to the store without deadlocking
+	type entry struct {
+		key   string
+		value []byte
+	}
+	var entries []entry
+	err := b.db.View(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket([]byte(bucket))
+		if bkt == nil {
+			return nil
+		}
+		c := bkt.Cursor()
+		p := []byte(prefix)
+		for k, v := c.Seek(p); k != nil && bytes.HasPrefix(k, p); k, v = c.Next() {
+			entries = append(entries, entry{key: string(k), value: clone(v)})
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, e := range entries {
+		if err := fn(e.key, e.value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *Bolt) Close() error {
+	return b.db.Close()
+}
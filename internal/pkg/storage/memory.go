@@ -0,0 +1,104 @@
+package storage
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Memory keeps everything in process memory. State is lost on restart, so
+// it is meant for development and single-replica deployments without
+// durability needs.
+type Memory struct {
+	mu      sync.RWMutex
+	buckets map[string]map[string][]byte
+}
+
+func NewMemory() *Memory {
+	return &Memory{buckets: make(map[string]map[string][]byte)}
+}
+
+func (m *Memory) Get(_ context.Context, bucket, key string) ([]byte, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	value, ok := m.buckets[bucket][key]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return clone(value), nil
+}
+
+func (m *Memory) Put(_ context.Context, bucket, key string, value []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.put(bucket, key, value)
+	return nil
+}
+
+func (m *Memory) Delete(_ context.Context, bucket, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.buckets[bucket], key)
+	return nil
+}
+
+func (m *Memory) Update(_ context.Context, bucket, key string, fn func(current []byte) ([]byte, error)) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	current, ok := m.buckets[bucket][key]
+	if ok {
+		current = clone(current)
+	}
+	next, err := fn(current)
+	if err != nil {
+		return err
+	}
+	if next == nil {
+		delete(m.buckets[bucket], key)
+		return nil
+	}
+	m.put(bucket, key, next)
+	return nil
+}
+
+func (m *Memory) Scan(_ context.Context, bucket, prefix string, fn func(key string, value []byte) error) error {
+	// Copy matching entries so fn may write to the store
+	m.mu.RLock()
+	keys := make([]string, 0, len(m.buckets[bucket]))
+	values := make(map[string][]byte)
+	for key, value := range m.buckets[bucket] {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+			values[key] = clone(value)
+		}
+	}
+	m.mu.RUnlock()
+
+	sort.Strings(keys)
+	for _, key := range keys {
+		if err := fn(key, values[key]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *Memory) Close() error {
+	return nil
+}
+
+func (m *Memory) put(bucket, key string, value []byte) {
+	b, ok := m.buckets[bucket]
+	if !ok {
+		b = make(map[string][]byte)
+		m.buckets[bucket] = b
+	}
+	b[key] = clone(value)
+}
+
+func clone(b []byte) []byte {
+	return append([]byte(nil), b...)
+}
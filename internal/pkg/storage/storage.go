@@ -0,0 +1,72 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"ride-sharing-notification/config"
+
+	"github.com/google/uuid"
+)
+
+// ErrNotFound is returned when a key does not exist
+var ErrNotFound = errors.New("not found")
+
+// KV is a bucketed key-value store shared by the subsystems that keep state
+// (preferences, devices, scheduled notifications, ...). Implementations must
+// be safe for concurrent use.
+type KV interface {
+	Get(ctx context.Context, bucket, key string) ([]byte, error)
+	Put(ctx context.Context, bucket, key string, value []byte) error
+	Delete(ctx context.Context, bucket, key string) error
+	// Update atomically replaces the value of key with the result of fn.
+	// fn receives nil when the key does not exist; returning nil deletes it.
+	Update(ctx context.Context, bucket, key string, fn func(current []byte) ([]byte, error)) error
+	// Scan calls fn for every key with prefix in ascending key order
+	Scan(ctx context.Context, bucket, prefix string, fn func(key string, value []byte) error) error
+	Close() error
+}
+
+// NewFromConfig opens the configured store
+func NewFromConfig(cfg *config.Config) (KV, error) {
+	switch cfg.Storage.Driver {
+	case "", "memory":
+		return NewMemory(), nil
+	case "bolt":
+		return OpenBolt(cfg.Storage.Path)
+	default:
+		return nil, fmt.Errorf("unknown storage driver: %s", cfg.Storage.Driver)
+	}
+}
+
+// GetJSON decodes the value of key into v
+func GetJSON(ctx context.Context, kv KV, bucket, key string, v any) error {
+	raw, err := kv.Get(ctx, bucket, key)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(raw, v); err != nil {
+		return fmt.Errorf("decode %s/%s: %w", bucket, key, err)
+	}
+	return nil
+}
+
+// PutJSON stores v encoded as JSON
+func PutJSON(ctx context.Context, kv KV, bucket, key string, v any) error {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("encode %s/%s: %w", bucket, key, err)
+	}
+	return kv.Put(ctx, bucket, key, raw)
+}
+
+// NewestFirstID returns a unique key for a record created at now. Keys of
+// newer records sort first, so a prefix scan returns the newest first.
+func NewestFirstID(now time.Time) string {
+	return fmt.Sprintf("%016x%s", math.MaxInt64-now.UnixNano(), strings.ReplaceAll(uuid.New().String(), "-", "")[:8])
+}
@@ -0,0 +1,264 @@
+package suppression
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"ride-sharing-notification/internal/pkg/errors"
+	"ride-sharing-notification/internal/pkg/storage"
+)
+
+const bucket = "suppressions"
+
+// Kinds of contact points that can be suppressed
+const (
+	KindEmail       = "email"
+	KindPhone       = "phone"
+	KindDeviceToken = "device_token"
+)
+
+// Reasons a contact point is suppressed
+const (
+	ReasonHardBounce   = "hard_bounce"
+	ReasonComplaint    = "complaint"
+	ReasonManual       = "manual"
+	ReasonUnsubscribed = "unsubscribed"
+)
+
+var (
+	kinds   = []string{KindEmail, KindPhone, KindDeviceToken}
+	reasons = []string{ReasonHardBounce, ReasonComplaint, ReasonManual, ReasonUnsubscribed}
+)
+
+var (
+	// ErrNotFound is returned for contact points that are not suppressed
+	ErrNotFound = stderrors.New("suppression not found")
+	// ErrSuppressed is the cause of the *errors.AppError returned by Check
+	ErrSuppressed = stderrors.New("recipient is suppressed")
+)
+
+// Entry stops messages to one contact point
+type Entry struct {
+	Kind   string `json:"kind"`
+	Value  string `json:"value"`
+	Reason string `json:"reason"`
+	// Detail explains the reason, e.g. the bounce diagnostic
+	Detail string `json:"detail,omitempty"`
+	// Source is where the entry came from, e.g. the bounce report source
+	Source    string    `json:"source,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	// ExpiresAt lifts the suppression; zero suppresses for good
+	ExpiresAt time.Time `json:"expires_at,omitzero"`
+}
+
+// Active reports whether the entry still suppresses its contact point
+func (e *Entry) Active(now time.Time) bool {
+	return e.ExpiresAt.IsZero() || now.Before(e.ExpiresAt)
+}
+
+// Query filters List; empty fields match everything
+type Query struct {
+	Kind   string
+	Value  string
+	Reason string
+}
+
+// List stores suppressed contact points
+type List struct {
+	kv  storage.KV
+	now func() time.Time
+}
+
+func NewList(kv storage.KV) *List {
+	return &List{kv: kv, now: time.Now}
+}
+
+// Add suppresses e.Value, replacing the reason and expiry of an existing
+// entry. Invalid input is returned as *errors.AppError.
+func (l *List) Add(ctx context.Context, e Entry) (*Entry, error) {
+	e.Value = Normalize(e.Kind, e.Value)
+	now := l.now().UTC()
+	if err := validate(&e, now); err != nil {
+		return nil, err
+	}
+	e.CreatedAt = now
+	e.UpdatedAt = now
+	if !e.ExpiresAt.IsZero() {
+		e.ExpiresAt = e.ExpiresAt.UTC()
+	}
+	if existing, err := l.Get(ctx, e.Kind, e.Value); err == nil {
+		e.CreatedAt = existing.CreatedAt
+	} else if !stderrors.Is(err, ErrNotFound) {
+		return nil, err
+	}
+	if err := storage.PutJSON(ctx, l.kv, bucket, key(e.Kind, e.Value), &e); err != nil {
+		return nil, fmt.Errorf("store suppression: %w", err)
+	}
+	return &e, nil
+}
+
+// Get returns the entry suppressing value. Expired entries are not found.
+func (l *List) Get(ctx context.Context, kind, value string) (*Entry, error) {
+	e := &Entry{}
+	if err := storage.GetJSON(ctx, l.kv, bucket, key(kind, Normalize(kind, value)), e); err != nil {
+		if stderrors.Is(err, storage.ErrNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	if !e.Active(l.now()) {
+		return nil, ErrNotFound
+	}
+	return e, nil
+}
+
+// Remove lifts the suppression of value
+func (l *List) Remove(ctx context.Context, kind, value string) error {
+	return l.kv.Update(ctx, bucket, key(kind, Normalize(kind, value)), func(current []byte) ([]byte, error) {
+		if current == nil {
+			return nil, ErrNotFound
+		}
+		return nil, nil
+	})
+}
+
+// List returns the active entries matching q, ordered by kind and value,
+// and how many there are in total
+func (l *List) List(ctx context.Context, q Query, page, perPage int) ([]Entry, int, error) {
+	prefix := ""
+	if q.Kind != "" {
+		prefix = q.Kind + "/"
+		if q.Value != "" {
+			prefix = key(q.Kind, Normalize(q.Kind, q.Value))
+		}
+	}
+	now := l.now()
+	var entries []Entry
+	err := l.kv.Scan(ctx, bucket, prefix, func(k string, value []byte) error {
+		var e Entry
+		if err := json.Unmarshal(value, &e); err != nil {
+			return fmt.Errorf("decode suppression %s: %w", k, err)
+		}
+		switch {
+		case !e.Active(now):
+		case q.Value != "" && e.Value != Normalize(e.Kind, q.Value):
+		case q.Reason != "" && e.Reason != q.Reason:
+		default:
+			entries = append(entries, e)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total := len(entries)
+	start := min((page-1)*perPage, total)
+	end := min(start+perPage, total)
+	return entries[start:end], total, nil
+}
+
+// Check returns an *errors.AppError wrapping ErrSuppressed when value is
+// suppressed, so senders can tell an intentional skip from a failure. A
+// nil list suppresses nothing.
+func (l *List) Check(ctx context.Context, kind, value string) error {
+	if l == nil {
+		return nil
+	}
+	e, err := l.Get(ctx, kind, value)
+	if stderrors.Is(err, ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("check suppression list: %w", err)
+	}
+	appErr := errors.NewSuppressedError(
+		fmt.Sprintf("%s is suppressed: %s", strings.ReplaceAll(kind, "_", " "), e.Reason),
+		ErrSuppressed,
+	)
+	appErr.Details = e
+	return appErr
+}
+
+// Expire deletes entries past their expiry and returns how many
+func (l *List) Expire(ctx context.Context) (int, error) {
+	now := l.now()
+	var stale []string
+	err := l.kv.Scan(ctx, bucket, "", func(k string, value []byte) error {
+		var e Entry
+		if err := json.Unmarshal(value, &e); err != nil {
+			return fmt.Errorf("decode suppression %s: %w", k, err)
+		}
+		if !e.Active(now) {
+			stale = append(stale, k)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	for i, k := range stale {
+		if err := l.kv.Delete(ctx, bucket, k); err != nil {
+			return i, err
+		}
+	}
+	return len(stale), nil
+}
+
+// Normalize returns the form value is stored under: emails are lowercased
+// and phone numbers lose their formatting
+func Normalize(kind, value string) string {
+	value = strings.TrimSpace(value)
+	switch kind {
+	case KindEmail:
+		value = strings.ToLower(value)
+	case KindPhone:
+		value = strings.Map(func(r rune) rune {
+			if strings.ContainsRune(" -().", r) {
+				return -1
+			}
+			return r
+		}, value)
+	}
+	return value
+}
+
+// ValidKind reports whether kind is a kind of contact point
+func ValidKind(kind string) bool {
+	return slices.Contains(kinds, kind)
+}
+
+// ValidReason reports whether reason is a known suppression reason
+func ValidReason(reason string) bool {
+	return slices.Contains(reasons, reason)
+}
+
+func validate(e *Entry, now time.Time) error {
+	violations := map[string]string{}
+	if !ValidKind(e.Kind) {
+		violations["kind"] = "kind must be one of " + strings.Join(kinds, ", ")
+	}
+	if e.Value == "" {
+		violations["value"] = "value is required"
+	}
+	if !ValidReason(e.Reason) {
+		violations["reason"] = "reason must be one of " + strings.Join(reasons, ", ")
+	}
+	if !e.ExpiresAt.IsZero() && !e.ExpiresAt.After(now) {
+		violations["expires_at"] = "expires_at must be in the future"
+	}
+	if len(violations) > 0 {
+		return errors.NewValidationError("invalid suppression", violations)
+	}
+	return nil
+}
+
+func key(kind, value string) string {
+	return kind + "/" + value
+}
@@ -0,0 +1,153 @@
+package throttle
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"ride-sharing-notification/config"
+
+	"golang.org/x/time/rate"
+)
+
+// Limit configures a token bucket: Rate tokens per second refill a bucket
+// holding at most Burst tokens
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+// Stats is a snapshot of how long sends queued behind a bucket
+type Stats struct {
+	Waits     uint64
+	Queued    int64
+	TotalWait time.Duration
+	MaxWait   time.Duration
+}
+
+type bucket struct {
+	limiter   *rate.Limiter
+	waits     atomic.Uint64
+	queued    atomic.Int64
+	totalWait atomic.Int64
+	maxWait   atomic.Int64
+}
+
+// Throttler paces outbound sends per channel or provider (e.g. "email" or
+// "sms:twilio"). Callers block until a token is available instead of being
+// rejected, so bursts are smoothed to what the provider accepts.
+type Throttler struct {
+	mu      sync.RWMutex
+	buckets map[string]*bucket
+}
+
+func New(limits map[string]Limit) *Throttler {
+	t := &Throttler{buckets: make(map[string]*bucket, len(limits))}
+	for key, limit := range limits {
+		t.Set(key, limit)
+	}
+	return t
+}
+
+// NewFromConfig builds a throttler from the configured provider limits
+func NewFromConfig(cfg *config.Config) *Throttler {
+	return New(LimitsFromConfig(cfg))
+}
+
+// LimitsFromConfig converts the configured provider limits
+func LimitsFromConfig(cfg *config.Config) map[string]Limit {
+	limits := make(map[string]Limit, len(cfg.Throttle))
+	for key, limit := range cfg.Throttle {
+		limits[key] = Limit{Rate: limit.Rate, Burst: limit.Burst}
+	}
+	return limits
+}
+
+// Replace applies limits and removes buckets for keys no longer listed.
+// Existing buckets are updated in place so queued sends keep their place.
+func (t *Throttler) Replace(limits map[string]Limit) {
+	t.mu.Lock()
+	for key := range t.buckets {
+		if _, ok := limits[key]; !ok {
+			delete(t.buckets, key)
+		}
+	}
+	t.mu.Unlock()
+
+	for key, limit := range limits {
+		t.Set(key, limit)
+	}
+}
+
+// Set installs or replaces the bucket for key. A non-positive rate removes
+// throttling for that key.
+func (t *Throttler) Set(key string, limit Limit) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if limit.Rate <= 0 {
+		delete(t.buckets, key)
+		return
+	}
+	burst := limit.Burst
+	if burst < 1 {
+		burst = 1
+	}
+
+	if b, ok := t.buckets[key]; ok {
+		b.limiter.SetLimit(rate.Limit(limit.Rate))
+		b.limiter.SetBurst(burst)
+		return
+	}
+	t.buckets[key] = &bucket{limiter: rate.NewLimiter(rate.Limit(limit.Rate), burst)}
+}
+
+// Wait blocks until key's bucket yields a token or ctx is done. Keys without
+// a configured bucket are never throttled.
+func (t *Throttler) Wait(ctx context.Context, key string) (time.Duration, error) {
+	if t == nil {
+		return 0, nil
+	}
+
+	t.mu.RLock()
+	b, ok := t.buckets[key]
+	t.mu.RUnlock()
+	if !ok {
+		return 0, nil
+	}
+
+	start := time.Now()
+	b.queued.Add(1)
+	err := b.limiter.Wait(ctx)
+	b.queued.Add(-1)
+	waited := time.Since(start)
+
+	b.waits.Add(1)
+	b.totalWait.Add(int64(waited))
+	for {
+		current := b.maxWait.Load()
+		if int64(waited) <= current || b.maxWait.CompareAndSwap(current, int64(waited)) {
+			break
+		}
+	}
+
+	return waited, err
+}
+
+// Stats returns queue statistics for every throttled key
+func (t *Throttler) Stats() map[string]Stats {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	stats := make(map[string]Stats, len(t.buckets))
+	for key, b := range t.buckets {
+		stats[key] = Stats{
+			Waits:     b.waits.Load(),
+			Queued:    b.queued.Load(),
+			TotalWait: time.Duration(b.totalWait.Load()),
+			MaxWait:   time.Duration(b.maxWait.Load()),
+		}
+	}
+	return stats
+}
@@ -0,0 +1,181 @@
+package tlsconfig
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"ride-sharing-notification/config"
+	"ride-sharing-notification/internal/pkg/logging"
+
+	"go.uber.org/zap"
+)
+
+// Reloader serves a certificate and client CA pool loaded from disk and
+// swaps them in when the files change, so rotated certificates are picked
+// up without a restart
+type Reloader struct {
+	certFile     string
+	keyFile      string
+	clientCAFile string
+	interval     time.Duration
+
+	mu       sync.RWMutex
+	cert     *tls.Certificate
+	clientCA *x509.CertPool
+	modTimes map[string]time.Time
+}
+
+func NewReloader(certFile, keyFile, clientCAFile string, interval time.Duration) (*Reloader, error) {
+	r := &Reloader{
+		certFile:     certFile,
+		keyFile:      keyFile,
+		clientCAFile: clientCAFile,
+		interval:     interval,
+	}
+	if err := r.load(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// NewFromConfig returns the server TLS configuration, or nil when TLS is disabled
+func NewFromConfig(ctx context.Context, cfg *config.Config) (*tls.Config, error) {
+	if !cfg.GRPC.TLS.Enabled {
+		return nil, nil
+	}
+
+	r, err := NewReloader(cfg.GRPC.TLS.CertFile, cfg.GRPC.TLS.KeyFile, cfg.GRPC.TLS.ClientCAFile, cfg.GRPC.TLS.ReloadInterval)
+	if err != nil {
+		return nil, err
+	}
+	go r.Watch(ctx)
+
+	return r.TLSConfig(), nil
+}
+
+// TLSConfig returns a server configuration backed by the reloader. Client
+// certificates are required and verified when a client CA is configured.
+func (r *Reloader) TLSConfig() *tls.Config {
+	base := &tls.Config{
+		MinVersion:     tls.VersionTLS12,
+		GetCertificate: r.getCertificate,
+	}
+	if r.clientCAFile == "" {
+		return base
+	}
+
+	// Resolve the CA pool per handshake so reloads apply to new connections
+	base.GetConfigForClient = func(*tls.ClientHelloInfo) (*tls.Config, error) {
+		r.mu.RLock()
+		defer r.mu.RUnlock()
+		return &tls.Config{
+			MinVersion:     tls.VersionTLS12,
+			GetCertificate: r.getCertificate,
+			ClientAuth:     tls.RequireAndVerifyClientCert,
+			ClientCAs:      r.clientCA,
+		}, nil
+	}
+	return base
+}
+
+// Watch polls the files until ctx is done and reloads them when any changes
+func (r *Reloader) Watch(ctx context.Context) {
+	if r.interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if !r.changed() {
+				continue
+			}
+			if err := r.load(); err != nil {
+				// Keep serving the previous certificate until the files are fixed
+				logging.GetLogger().Error("failed to reload TLS certificates", zap.Error(err))
+				continue
+			}
+			logging.GetLogger().Info("reloaded TLS certificates",
+				zap.String("cert_file", r.certFile),
+				zap.String("client_ca_file", r.clientCAFile),
+			)
+		}
+	}
+}
+
+func (r *Reloader) getCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.cert, nil
+}
+
+func (r *Reloader) load() error {
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("load server certificate: %w", err)
+	}
+
+	var pool *x509.CertPool
+	if r.clientCAFile != "" {
+		pem, err := os.ReadFile(r.clientCAFile)
+		if err != nil {
+			return fmt.Errorf("read client CA: %w", err)
+		}
+		pool = x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("client CA %s contains no certificates", r.clientCAFile)
+		}
+	}
+
+	modTimes, err := r.statFiles()
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	r.cert = &cert
+	r.clientCA = pool
+	r.modTimes = modTimes
+	r.mu.Unlock()
+	return nil
+}
+
+func (r *Reloader) changed() bool {
+	current, err := r.statFiles()
+	if err != nil {
+		return false
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for file, mod := range current {
+		if !mod.Equal(r.modTimes[file]) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Reloader) statFiles() (map[string]time.Time, error) {
+	modTimes := make(map[string]time.Time, 3)
+	for _, file := range []string{r.certFile, r.keyFile, r.clientCAFile} {
+		if file == "" {
+			continue
+		}
+		info, err := os.Stat(file)
+		if err != nil {
+			return nil, fmt.Errorf("stat %s: %w", file, err)
+		}
+		modTimes[file] = info.ModTime()
+	}
+	return modTimes, nil
+}
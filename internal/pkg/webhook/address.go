@@ -0,0 +1,115 @@
+package webhook
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"syscall"
+	"time"
+
+	"ride-sharing-notification/internal/pkg/errors"
+)
+
+// ErrPrivateAddress is returned for endpoints that resolve to an address
+// webhooks may not be delivered to
+var ErrPrivateAddress = stderrors.New("webhook endpoint resolves to a private address")
+
+// blockedPrefixes are special-purpose ranges not covered by the netip
+// predicates used in publicAddress
+var blockedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("192.0.2.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("198.51.100.0/24"),
+	netip.MustParsePrefix("203.0.113.0/24"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+	netip.MustParsePrefix("64:ff9b::/96"),
+	netip.MustParsePrefix("2001:db8::/32"),
+}
+
+// publicAddress reports whether ip is routable on the internet. Loopback,
+// private, link-local (including the 169.254.169.254 metadata service),
+// multicast and reserved addresses are not.
+func publicAddress(ip netip.Addr) bool {
+	ip = ip.Unmap()
+	if !ip.IsValid() || ip.IsUnspecified() || ip.IsLoopback() || ip.IsPrivate() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() ||
+		ip.IsMulticast() {
+		return false
+	}
+	for _, prefix := range blockedPrefixes {
+		if prefix.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// checkAddress resolves the host of rawURL and rejects it unless every
+// address is public. Private endpoints are allowed by
+// Settings.AllowPrivateNetworks.
+func (s *Service) checkAddress(ctx context.Context, rawURL string) error {
+	if s.settings.AllowPrivateNetworks {
+		return nil
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return errors.NewValidationError("invalid webhook subscription", map[string]string{
+			"url": "url must be an absolute URL",
+		})
+	}
+	ips, err := net.DefaultResolver.LookupNetIP(ctx, "ip", u.Hostname())
+	if err != nil || len(ips) == 0 {
+		return errors.NewValidationError("invalid webhook subscription", map[string]string{
+			"url": "url host cannot be resolved",
+		})
+	}
+	for _, ip := range ips {
+		if !publicAddress(ip) {
+			return errors.NewValidationError("invalid webhook subscription", map[string]string{
+				"url": "url must not resolve to a private address",
+			})
+		}
+	}
+	return nil
+}
+
+// dialControl refuses connections to non-public addresses. It runs after
+// DNS resolution, so a host that resolved to a public address when the
+// subscription was saved cannot be rebound to a private one.
+func dialControl(_, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return fmt.Errorf("parse dial address %q: %w", address, err)
+	}
+	if !publicAddress(addrPort.Addr()) {
+		return fmt.Errorf("%w: %s", ErrPrivateAddress, addrPort.Addr())
+	}
+	return nil
+}
+
+// newTransport returns the transport deliveries are sent with. Proxies
+// are not used, so the dial check sees the endpoint's own address.
+func newTransport(allowPrivate bool) *http.Transport {
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+	}
+	if !allowPrivate {
+		dialer.Control = dialControl
+	}
+	return &http.Transport{
+		DialContext:           dialer.DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          100,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: time.Second,
+	}
+}
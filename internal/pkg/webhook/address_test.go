@@ -0,0 +1,88 @@
+package webhook
+
+import (
+	"context"
+	stderrors "errors"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+
+	"ride-sharing-notification/internal/pkg/storage"
+)
+
+func TestPublicAddress(t *testing.T) {
+	tests := []struct {
+		addr string
+		want bool
+	}{
+		{"93.184.216.34", true},
+		{"2606:2800:220:1:248:1893:25c8:1946", true},
+		{"127.0.0.1", false},
+		{"::1", false},
+		{"10.1.2.3", false},
+		{"172.16.0.1", false},
+		{"192.168.1.1", false},
+		{"169.254.169.254", false},
+		{"fe80::1", false},
+		{"fd00::1", false},
+		{"100.64.0.1", false},
+		{"0.0.0.0", false},
+		{"::", false},
+		{"224.0.0.1", false},
+		{"::ffff:127.0.0.1", false},
+		{"::ffff:169.254.169.254", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.addr, func(t *testing.T) {
+			if got := publicAddress(netip.MustParseAddr(tt.addr)); got != tt.want {
+				t.Errorf("publicAddress(%s) = %v, want %v", tt.addr, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckAddress(t *testing.T) {
+	tests := []struct {
+		name         string
+		url          string
+		allowPrivate bool
+		wantErr      bool
+	}{
+		{"public", "https://93.184.216.34/hook", false, false},
+		{"loopback", "https://127.0.0.1/hook", false, true},
+		{"localhost", "https://localhost/hook", false, true},
+		{"metadata", "http://169.254.169.254/latest/meta-data", false, true},
+		{"private", "https://10.0.0.5:8443/hook", false, true},
+		{"private allowed", "https://10.0.0.5:8443/hook", true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewService(storage.NewMemory(), Settings{AllowPrivateNetworks: tt.allowPrivate})
+			err := s.checkAddress(context.Background(), tt.url)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("checkAddress(%q) error = %v, want error %v", tt.url, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTransportRefusesPrivateAddresses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: newTransport(false)}
+	_, err := client.Get(server.URL)
+	if !stderrors.Is(err, ErrPrivateAddress) {
+		t.Fatalf("Get(%s) error = %v, want %v", server.URL, err, ErrPrivateAddress)
+	}
+
+	client = &http.Client{Transport: newTransport(true)}
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("Get(%s) with private networks allowed: %v", server.URL, err)
+	}
+	resp.Body.Close()
+}
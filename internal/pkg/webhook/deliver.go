@@ -0,0 +1,409 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"ride-sharing-notification/internal/pkg/logging"
+	"ride-sharing-notification/internal/pkg/metrics"
+	"ride-sharing-notification/internal/pkg/storage"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// queueBucket holds pending deliveries keyed by their next attempt time
+	queueBucket = "webhook_queue"
+	// dueKeyLayout sorts queue keys by attempt time
+	dueKeyLayout = "20060102T150405.000000000Z"
+)
+
+// Headers sent with every delivery
+const (
+	HeaderID        = "X-Webhook-Id"
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	// HeaderSignature is "sha256=" and the hex HMAC-SHA256 of the timestamp
+	// header, a dot and the body, keyed with the subscription secret
+	HeaderSignature = "X-Webhook-Signature"
+)
+
+// errStopScan ends a scan early without reporting an error
+var errStopScan = stderrors.New("stop scan")
+
+// Event is a ride-service event for the subscriptions of an account.
+// Receivers deduplicate on ID, since delivery is at least once.
+type Event struct {
+	ID        string
+	Type      string
+	AccountID string
+	Data      map[string]interface{}
+}
+
+// envelope is the JSON body of a delivery
+type envelope struct {
+	ID        string                 `json:"id"`
+	Type      string                 `json:"type"`
+	CreatedAt time.Time              `json:"created_at"`
+	Data      map[string]interface{} `json:"data"`
+}
+
+// Sign returns the signature header value of body sent at timestamp
+func Sign(secret string, timestamp time.Time, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp.Unix(), 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Publish queues e for every enabled subscription of its account that
+// wants its type and returns how many deliveries were queued
+func (s *Service) Publish(ctx context.Context, e Event) (int, error) {
+	subs, err := s.List(ctx, e.AccountID)
+	if err != nil {
+		return 0, err
+	}
+	now := s.now().UTC()
+	body, err := json.Marshal(envelope{ID: e.ID, Type: e.Type, CreatedAt: now, Data: e.Data})
+	if err != nil {
+		return 0, fmt.Errorf("encode webhook event: %w", err)
+	}
+
+	queued := 0
+	for _, sub := range subs {
+		if !sub.Enabled || !sub.Wants(e.Type) {
+			continue
+		}
+		d := &Delivery{
+			ID:             storage.NewestFirstID(now),
+			SubscriptionID: sub.ID,
+			EventID:        e.ID,
+			EventType:      e.Type,
+			Body:           body,
+			Status:         StatusPending,
+			CreatedAt:      now,
+			NextAttemptAt:  now,
+		}
+		if err := storage.PutJSON(ctx, s.kv, deliveryBucket, deliveryKey(sub.ID, d.ID), d); err != nil {
+			return queued, fmt.Errorf("store webhook delivery: %w", err)
+		}
+		if err := s.enqueue(ctx, now, sub.ID, d.ID); err != nil {
+			return queued, fmt.Errorf("queue webhook delivery: %w", err)
+		}
+		queued++
+		if err := s.trimLog(ctx, sub.ID); err != nil {
+			logging.GetLogger().WithContext(ctx).Warn("failed to trim webhook delivery log",
+				zap.String("subscription_id", sub.ID), zap.Error(err))
+		}
+	}
+	if queued > 0 {
+		select {
+		case s.wake <- struct{}{}:
+		default:
+		}
+	}
+	return queued, nil
+}
+
+// Run attempts queued deliveries as they fall due, checking every interval
+// and right after events are published, until ctx is done
+func (s *Service) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-s.wake:
+		}
+		s.deliverDue(ctx)
+	}
+}
+
+func (s *Service) deliverDue(ctx context.Context) {
+	logger := logging.GetLogger()
+	cutoff := s.now().UTC().Format(dueKeyLayout) + "0"
+	var due []string
+	err := s.kv.Scan(ctx, queueBucket, "", func(key string, _ []byte) error {
+		if key > cutoff {
+			return errStopScan
+		}
+		due = append(due, key)
+		return nil
+	})
+	if err != nil && !stderrors.Is(err, errStopScan) {
+		logger.Error("failed to load webhook queue", zap.Error(err))
+		return
+	}
+
+	sem := make(chan struct{}, max(s.settings.Concurrency, 1))
+	var wg sync.WaitGroup
+	for _, key := range due {
+		if ctx.Err() != nil {
+			break
+		}
+		leased, ok, err := s.claim(ctx, key)
+		if err != nil {
+			logger.Error("failed to claim webhook delivery", zap.String("queue_key", key), zap.Error(err))
+			continue
+		}
+		if !ok {
+			continue
+		}
+		sem <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer func() { <-sem; wg.Done() }()
+			s.attempt(ctx, leased)
+		}()
+	}
+	wg.Wait()
+}
+
+// claim moves the queue entry one lease into the future so other workers
+// skip it; if this one dies mid-attempt the entry comes due again. ok is
+// false when another worker claimed it first.
+func (s *Service) claim(ctx context.Context, key string) (string, bool, error) {
+	_, subID, deliveryID := parseQueueKey(key)
+	leased := queueKey(s.now().Add(s.settings.Lease), subID, deliveryID)
+	if err := s.kv.Put(ctx, queueBucket, leased, []byte(deliveryKey(subID, deliveryID))); err != nil {
+		return "", false, err
+	}
+	existed := false
+	err := s.kv.Update(ctx, queueBucket, key, func(current []byte) ([]byte, error) {
+		existed = current != nil
+		return nil, nil
+	})
+	if err != nil || !existed {
+		_ = s.kv.Delete(ctx, queueBucket, leased)
+		return "", false, err
+	}
+	return leased, true, nil
+}
+
+// attempt sends a claimed delivery and records the result. Failed
+// deliveries are queued again with backoff until MaxAttempts.
+func (s *Service) attempt(ctx context.Context, queued string) {
+	_, subID, deliveryID := parseQueueKey(queued)
+	logger := logging.GetLogger().With(
+		zap.String("subscription_id", subID),
+		zap.String("delivery_id", deliveryID),
+	)
+	done := func() {
+		if err := s.kv.Delete(context.WithoutCancel(ctx), queueBucket, queued); err != nil {
+			logger.Error("failed to remove webhook delivery from queue", zap.Error(err))
+		}
+	}
+
+	sub, err := s.Get(ctx, subID)
+	if stderrors.Is(err, ErrNotFound) {
+		done()
+		return
+	}
+	if err != nil {
+		logger.Error("failed to load webhook subscription", zap.Error(err))
+		return
+	}
+	d := &Delivery{}
+	err = storage.GetJSON(ctx, s.kv, deliveryBucket, deliveryKey(subID, deliveryID), d)
+	if stderrors.Is(err, storage.ErrNotFound) {
+		done()
+		return
+	}
+	if err != nil {
+		logger.Error("failed to load webhook delivery", zap.Error(err))
+		return
+	}
+	if !sub.Enabled {
+		d.Status = StatusFailed
+		d.Error = "subscription disabled"
+		d.NextAttemptAt = time.Time{}
+		s.record(ctx, logger, d)
+		done()
+		return
+	}
+
+	code, sendErr := s.send(ctx, sub, d)
+	now := s.now().UTC()
+	d.Attempts++
+	d.LastAttemptAt = now
+	d.ResponseCode = code
+	d.Error = ""
+	d.NextAttemptAt = time.Time{}
+
+	if sendErr == nil {
+		d.Status = StatusDelivered
+		d.DeliveredAt = now
+		metrics.WebhookDeliveriesTotal.WithLabelValues(StatusDelivered).Inc()
+		if sub.ConsecutiveFailures > 0 {
+			s.updateHealth(ctx, logger, subID, true)
+		}
+		s.record(ctx, logger, d)
+		done()
+		return
+	}
+
+	d.Error = sendErr.Error()
+	disabled := s.updateHealth(ctx, logger, subID, false)
+	if disabled || d.Attempts >= s.settings.MaxAttempts {
+		d.Status = StatusFailed
+		metrics.WebhookDeliveriesTotal.WithLabelValues(StatusFailed).Inc()
+		logger.Warn("giving up on webhook delivery", zap.Int("attempts", d.Attempts), zap.Error(sendErr))
+		s.record(ctx, logger, d)
+		done()
+		return
+	}
+
+	d.NextAttemptAt = now.Add(s.backoff(d.Attempts))
+	metrics.WebhookDeliveriesTotal.WithLabelValues("retrying").Inc()
+	logger.Info("webhook delivery failed, retrying later",
+		zap.Int("attempt", d.Attempts),
+		zap.Time("next_attempt_at", d.NextAttemptAt),
+		zap.Error(sendErr),
+	)
+	s.record(ctx, logger, d)
+	// The lease brings the delivery back if requeueing fails
+	if err := s.enqueue(ctx, d.NextAttemptAt, subID, d.ID); err != nil {
+		logger.Error("failed to requeue webhook delivery", zap.Error(err))
+		return
+	}
+	done()
+}
+
+// send posts the delivery body, signed with the subscription secret. Any
+// 2xx response is a success.
+func (s *Service) send(ctx context.Context, sub *Subscription, d *Delivery) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.URL, bytes.NewReader(d.Body))
+	if err != nil {
+		return 0, err
+	}
+	timestamp := s.now()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "ride-sharing-notification-webhooks")
+	req.Header.Set(HeaderID, d.ID)
+	req.Header.Set(HeaderEvent, d.EventType)
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp.Unix(), 10))
+	req.Header.Set(HeaderSignature, Sign(sub.Secret, timestamp, d.Body))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("endpoint returned %s", resp.Status)
+	}
+	return resp.StatusCode, nil
+}
+
+// updateHealth resets or counts the subscription's consecutive failures and
+// reports whether it is disabled now
+func (s *Service) updateHealth(ctx context.Context, logger *logging.Logger, subID string, ok bool) bool {
+	disabledNow := false
+	sub, err := s.update(ctx, subID, func(sub *Subscription) error {
+		if ok {
+			sub.ConsecutiveFailures = 0
+			return nil
+		}
+		sub.ConsecutiveFailures++
+		if sub.Enabled && s.settings.DisableAfter > 0 && sub.ConsecutiveFailures >= s.settings.DisableAfter {
+			sub.Enabled = false
+			sub.DisabledReason = fmt.Sprintf("disabled after %d consecutive failed attempts", sub.ConsecutiveFailures)
+			sub.UpdatedAt = s.now().UTC()
+			disabledNow = true
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Error("failed to update webhook subscription health", zap.Error(err))
+		return false
+	}
+	if disabledNow {
+		metrics.WebhookSubscriptionsDisabledTotal.Inc()
+		logger.Warn("disabled failing webhook subscription", zap.String("url", sub.URL))
+	}
+	return !sub.Enabled
+}
+
+func (s *Service) record(ctx context.Context, logger *logging.Logger, d *Delivery) {
+	if err := storage.PutJSON(context.WithoutCancel(ctx), s.kv, deliveryBucket, deliveryKey(d.SubscriptionID, d.ID), d); err != nil {
+		logger.Error("failed to record webhook delivery", zap.Error(err))
+	}
+}
+
+// backoff doubles from InitialBackoff with up to 20% jitter
+func (s *Service) backoff(attempts int) time.Duration {
+	wait := s.settings.InitialBackoff
+	for i := 1; i < attempts && wait < s.settings.MaxBackoff; i++ {
+		wait *= 2
+	}
+	wait = min(wait, s.settings.MaxBackoff)
+	return wait + time.Duration(rand.Int64N(int64(wait)/5+1))
+}
+
+// trimLog deletes the oldest finished deliveries beyond LogLimit
+func (s *Service) trimLog(ctx context.Context, subID string) error {
+	if s.settings.LogLimit <= 0 {
+		return nil
+	}
+	var excess []string
+	seen := 0
+	err := s.kv.Scan(ctx, deliveryBucket, subID+"/", func(key string, value []byte) error {
+		seen++
+		if seen <= s.settings.LogLimit {
+			return nil
+		}
+		var d Delivery
+		if err := json.Unmarshal(value, &d); err != nil {
+			return fmt.Errorf("decode webhook delivery %s: %w", key, err)
+		}
+		if d.Status != StatusPending {
+			excess = append(excess, key)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for _, key := range excess {
+		if err := s.kv.Delete(ctx, deliveryBucket, key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// enqueue schedules an attempt at due. The value is the delivery's key so
+// claim can tell a stored entry from a missing one.
+func (s *Service) enqueue(ctx context.Context, due time.Time, subID, deliveryID string) error {
+	return s.kv.Put(ctx, queueBucket, queueKey(due, subID, deliveryID), []byte(deliveryKey(subID, deliveryID)))
+}
+
+func queueKey(due time.Time, subID, deliveryID string) string {
+	return due.UTC().Format(dueKeyLayout) + "/" + subID + "/" + deliveryID
+}
+
+func parseQueueKey(key string) (due, subID, deliveryID string) {
+	parts := strings.SplitN(key, "/", 3)
+	if len(parts) != 3 {
+		return key, "", ""
+	}
+	return parts[0], parts[1], parts[2]
+}
@@ -0,0 +1,105 @@
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"ride-sharing-notification/internal/pkg/storage"
+)
+
+func TestSign(t *testing.T) {
+	at := time.Unix(1700000000, 0)
+	body := []byte(`{"id":"evt_1"}`)
+	tests := []struct {
+		name      string
+		secret    string
+		timestamp time.Time
+		body      []byte
+		want      string
+	}{
+		{"reference", "whsec_test", at, body, "sha256=c89214b5b5da833daed6f0b8c5bb6bd58cea9022bd80ccc78230f3942d632925"},
+		{"other secret", "whsec_other", at, body, "sha256=d8d091c76b586cff4dbd317fc47ebddff4b86de3ce18d3c03753c3c1901d475a"},
+		{"later timestamp", "whsec_test", at.Add(time.Second), body, "sha256=a6b8e4670849f25456dbcceec15faae9edf44ea78d5607a06ebcb96ce7583658"},
+		{"fractional seconds ignored", "whsec_test", at.Add(999 * time.Millisecond), body, "sha256=c89214b5b5da833daed6f0b8c5bb6bd58cea9022bd80ccc78230f3942d632925"},
+		{"time zone ignored", "whsec_test", at.In(time.FixedZone("UTC+5", 5*60*60)), body, "sha256=c89214b5b5da833daed6f0b8c5bb6bd58cea9022bd80ccc78230f3942d632925"},
+		{"empty body", "whsec_test", at, nil, "sha256=5967f3c560522fa40cf2876ebc3c3a08551dd6959aaade3b413460591895bdcc"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Sign(tt.secret, tt.timestamp, tt.body); got != tt.want {
+				t.Errorf("Sign() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSendSignsDelivery(t *testing.T) {
+	const secret = "whsec_0123456789abcdef"
+	now := time.Unix(1700000000, 0)
+	body := []byte(`{"id":"evt_1","type":"ride.trip_completed"}`)
+
+	tests := []struct {
+		name     string
+		status   int
+		wantCode int
+		wantErr  bool
+	}{
+		{"accepted", http.StatusOK, http.StatusOK, false},
+		{"no content", http.StatusNoContent, http.StatusNoContent, false},
+		{"redirect is not a delivery", http.StatusFound, http.StatusFound, true},
+		{"server error", http.StatusInternalServerError, http.StatusInternalServerError, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var header http.Header
+			var received []byte
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				header = r.Header
+				received, _ = io.ReadAll(r.Body)
+				if tt.status == http.StatusFound {
+					w.Header().Set("Location", "/elsewhere")
+				}
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			s := NewService(storage.NewMemory(), Settings{Timeout: time.Second, AllowPrivateNetworks: true})
+			s.now = func() time.Time { return now }
+			code, err := s.send(context.Background(),
+				&Subscription{ID: "sub_1", URL: server.URL, Secret: secret},
+				&Delivery{ID: "del_1", EventType: "ride.trip_completed", Body: body},
+			)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("send() error = %v, want error %v", err, tt.wantErr)
+			}
+			if code != tt.wantCode {
+				t.Errorf("send() code = %d, want %d", code, tt.wantCode)
+			}
+
+			if string(received) != string(body) {
+				t.Errorf("received body %s, want %s", received, body)
+			}
+			if got := header.Get(HeaderID); got != "del_1" {
+				t.Errorf("%s = %q, want del_1", HeaderID, got)
+			}
+			if got := header.Get(HeaderEvent); got != "ride.trip_completed" {
+				t.Errorf("%s = %q, want ride.trip_completed", HeaderEvent, got)
+			}
+			// Verify the way a receiver would, from the headers alone
+			ts, err := strconv.ParseInt(header.Get(HeaderTimestamp), 10, 64)
+			if err != nil || ts != now.Unix() {
+				t.Fatalf("%s = %q, want %d", HeaderTimestamp, header.Get(HeaderTimestamp), now.Unix())
+			}
+			want := Sign(secret, time.Unix(ts, 0), received)
+			if got := header.Get(HeaderSignature); !hmac.Equal([]byte(got), []byte(want)) {
+				t.Errorf("%s = %q, want %q", HeaderSignature, got, want)
+			}
+		})
+	}
+}
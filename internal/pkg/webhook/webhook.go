@@ -0,0 +1,358 @@
+package webhook
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"ride-sharing-notification/config"
+	"ride-sharing-notification/internal/pkg/errors"
+	"ride-sharing-notification/internal/pkg/storage"
+
+	"github.com/google/uuid"
+)
+
+const (
+	subscriptionBucket = "webhooks"
+	// deliveryBucket holds the delivery log of each subscription, newest
+	// first
+	deliveryBucket = "webhook_deliveries"
+	// minSecretLength applies to secrets chosen by the caller
+	minSecretLength = 16
+)
+
+// AllEvents subscribes to every event type
+const AllEvents = "*"
+
+// Delivery statuses
+const (
+	StatusPending   = "pending"
+	StatusDelivered = "delivered"
+	StatusFailed    = "failed"
+)
+
+// ErrNotFound is returned for unknown or deleted subscriptions
+var ErrNotFound = stderrors.New("webhook subscription not found")
+
+// Subscription sends an account's events of the listed types to URL
+type Subscription struct {
+	ID         string   `json:"id"`
+	AccountID  string   `json:"account_id"`
+	URL        string   `json:"url"`
+	EventTypes []string `json:"event_types"`
+	// Secret signs every delivery; it is only shown when created or rotated
+	Secret  string `json:"secret"`
+	Enabled bool   `json:"enabled"`
+	// ConsecutiveFailures counts failed attempts since the last success
+	ConsecutiveFailures int `json:"consecutive_failures"`
+	// DisabledReason is set when the subscription was disabled for failing
+	DisabledReason string    `json:"disabled_reason,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// Wants reports whether the subscription receives eventType
+func (s *Subscription) Wants(eventType string) bool {
+	return slices.Contains(s.EventTypes, eventType) || slices.Contains(s.EventTypes, AllEvents)
+}
+
+// Delivery is one event sent, or being sent, to a subscription
+type Delivery struct {
+	ID             string          `json:"id"`
+	SubscriptionID string          `json:"subscription_id"`
+	EventID        string          `json:"event_id"`
+	EventType      string          `json:"event_type"`
+	Body           json.RawMessage `json:"body"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	// ResponseCode and Error describe the last attempt
+	ResponseCode  int       `json:"response_code,omitempty"`
+	Error         string    `json:"error,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+	LastAttemptAt time.Time `json:"last_attempt_at,omitzero"`
+	NextAttemptAt time.Time `json:"next_attempt_at,omitzero"`
+	DeliveredAt   time.Time `json:"delivered_at,omitzero"`
+}
+
+// Change lists the fields Update sets; nil and empty fields are kept
+type Change struct {
+	URL        string
+	EventTypes []string
+	Enabled    *bool
+	// RotateSecret replaces the secret with a new random one
+	RotateSecret bool
+}
+
+// Settings control delivery and retries
+type Settings struct {
+	// Timeout bounds each attempt
+	Timeout time.Duration
+	// MaxAttempts per delivery before it is marked failed
+	MaxAttempts int
+	// InitialBackoff doubles after every failed attempt up to MaxBackoff
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	// DisableAfter consecutive failed attempts disables the subscription
+	DisableAfter int
+	// Lease is how long a claimed delivery is hidden from other workers
+	Lease time.Duration
+	// Concurrency is how many deliveries are attempted at once
+	Concurrency int
+	// LogLimit is how many deliveries are kept per subscription
+	LogLimit int
+	// AllowHTTP accepts plain http URLs, e.g. for local testing
+	AllowHTTP bool
+	// AllowPrivateNetworks accepts endpoints on loopback and private
+	// addresses, e.g. for local testing
+	AllowPrivateNetworks bool
+}
+
+// Service manages webhook subscriptions and delivers events to them.
+// Deliveries are queued in storage and attempted by Run, so an event is
+// delivered at least once even if the process restarts in between.
+type Service struct {
+	kv       storage.KV
+	settings Settings
+	client   *http.Client
+	now      func() time.Time
+	// wake starts a delivery round as soon as events are published
+	wake chan struct{}
+}
+
+func NewService(kv storage.KV, settings Settings) *Service {
+	return &Service{
+		kv:       kv,
+		settings: settings,
+		client: &http.Client{
+			Transport: newTransport(settings.AllowPrivateNetworks),
+			Timeout:   settings.Timeout,
+			// A redirect is not a successful delivery
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		now:  time.Now,
+		wake: make(chan struct{}, 1),
+	}
+}
+
+func NewFromConfig(kv storage.KV, cfg *config.Config) *Service {
+	return NewService(kv, Settings{
+		Timeout:              cfg.Webhook.Timeout,
+		MaxAttempts:          cfg.Webhook.MaxAttempts,
+		InitialBackoff:       cfg.Webhook.InitialBackoff,
+		MaxBackoff:           cfg.Webhook.MaxBackoff,
+		DisableAfter:         cfg.Webhook.DisableAfter,
+		Lease:                cfg.Webhook.Lease,
+		Concurrency:          cfg.Webhook.Concurrency,
+		LogLimit:             cfg.Webhook.LogLimit,
+		AllowHTTP:            cfg.Webhook.AllowHTTP,
+		AllowPrivateNetworks: cfg.Webhook.AllowPrivateNetworks,
+	})
+}
+
+// Create stores a new enabled subscription. A random secret is generated
+// unless one is given. Invalid input is returned as *errors.AppError.
+func (s *Service) Create(ctx context.Context, sub Subscription) (*Subscription, error) {
+	now := s.now().UTC()
+	sub.ID = uuid.New().String()
+	sub.Enabled = true
+	sub.ConsecutiveFailures = 0
+	sub.DisabledReason = ""
+	sub.CreatedAt = now
+	sub.UpdatedAt = now
+	if sub.Secret == "" {
+		sub.Secret = newSecret()
+	}
+	if err := s.validate(&sub); err != nil {
+		return nil, err
+	}
+	if err := s.checkAddress(ctx, sub.URL); err != nil {
+		return nil, err
+	}
+	if err := storage.PutJSON(ctx, s.kv, subscriptionBucket, sub.ID, &sub); err != nil {
+		return nil, fmt.Errorf("store webhook subscription: %w", err)
+	}
+	return &sub, nil
+}
+
+// Get returns the subscription with id
+func (s *Service) Get(ctx context.Context, id string) (*Subscription, error) {
+	sub := &Subscription{}
+	if err := storage.GetJSON(ctx, s.kv, subscriptionBucket, id, sub); err != nil {
+		if stderrors.Is(err, storage.ErrNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return sub, nil
+}
+
+// List returns the subscriptions of accountID, or all when it is empty
+func (s *Service) List(ctx context.Context, accountID string) ([]Subscription, error) {
+	var subs []Subscription
+	err := s.kv.Scan(ctx, subscriptionBucket, "", func(key string, value []byte) error {
+		var sub Subscription
+		if err := json.Unmarshal(value, &sub); err != nil {
+			return fmt.Errorf("decode webhook subscription %s: %w", key, err)
+		}
+		if accountID == "" || sub.AccountID == accountID {
+			subs = append(subs, sub)
+		}
+		return nil
+	})
+	return subs, err
+}
+
+// Update applies change to the subscription with id. Enabling it again
+// clears its failure count.
+func (s *Service) Update(ctx context.Context, id string, change Change) (*Subscription, error) {
+	if change.URL != "" {
+		if err := s.checkAddress(ctx, change.URL); err != nil {
+			return nil, err
+		}
+	}
+	return s.update(ctx, id, func(sub *Subscription) error {
+		if change.URL != "" {
+			sub.URL = change.URL
+		}
+		if len(change.EventTypes) > 0 {
+			sub.EventTypes = change.EventTypes
+		}
+		if change.Enabled != nil {
+			if *change.Enabled && !sub.Enabled {
+				sub.ConsecutiveFailures = 0
+				sub.DisabledReason = ""
+			}
+			sub.Enabled = *change.Enabled
+		}
+		if change.RotateSecret {
+			sub.Secret = newSecret()
+		}
+		sub.UpdatedAt = s.now().UTC()
+		return s.validate(sub)
+	})
+}
+
+// Delete removes the subscription and its delivery log. Queued deliveries
+// are dropped when they come due.
+func (s *Service) Delete(ctx context.Context, id string) error {
+	if _, err := s.Get(ctx, id); err != nil {
+		return err
+	}
+	if err := s.kv.Delete(ctx, subscriptionBucket, id); err != nil {
+		return err
+	}
+	var keys []string
+	err := s.kv.Scan(ctx, deliveryBucket, id+"/", func(key string, _ []byte) error {
+		keys = append(keys, key)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		if err := s.kv.Delete(ctx, deliveryBucket, key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Deliveries returns one page of the subscription's delivery log, newest
+// first, and the number of deliveries with status, or all when it is
+// empty. Pages start at 1.
+func (s *Service) Deliveries(ctx context.Context, subscriptionID, status string, page, perPage int) ([]Delivery, int, error) {
+	if _, err := s.Get(ctx, subscriptionID); err != nil {
+		return nil, 0, err
+	}
+	var deliveries []Delivery
+	err := s.kv.Scan(ctx, deliveryBucket, subscriptionID+"/", func(key string, value []byte) error {
+		var d Delivery
+		if err := json.Unmarshal(value, &d); err != nil {
+			return fmt.Errorf("decode webhook delivery %s: %w", key, err)
+		}
+		if status == "" || d.Status == status {
+			deliveries = append(deliveries, d)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total := len(deliveries)
+	start := min((page-1)*perPage, total)
+	end := min(start+perPage, total)
+	return deliveries[start:end], total, nil
+}
+
+// update changes the stored subscription with id in one transaction
+func (s *Service) update(ctx context.Context, id string, fn func(*Subscription) error) (*Subscription, error) {
+	var updated *Subscription
+	err := s.kv.Update(ctx, subscriptionBucket, id, func(current []byte) ([]byte, error) {
+		if current == nil {
+			return nil, ErrNotFound
+		}
+		sub := &Subscription{}
+		if err := json.Unmarshal(current, sub); err != nil {
+			return nil, fmt.Errorf("decode webhook subscription %s: %w", id, err)
+		}
+		if err := fn(sub); err != nil {
+			return nil, err
+		}
+		updated = sub
+		return json.Marshal(sub)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+func (s *Service) validate(sub *Subscription) error {
+	violations := map[string]string{}
+	if sub.AccountID == "" {
+		violations["account_id"] = "account_id is required"
+	}
+	u, err := url.Parse(sub.URL)
+	switch {
+	case sub.URL == "" || err != nil || u.Host == "":
+		violations["url"] = "url must be an absolute URL"
+	case u.Scheme != "https" && !(u.Scheme == "http" && s.settings.AllowHTTP):
+		violations["url"] = "url must use https"
+	}
+	if len(sub.EventTypes) == 0 {
+		violations["event_types"] = "at least one event type is required"
+	}
+	for _, eventType := range sub.EventTypes {
+		if strings.TrimSpace(eventType) == "" {
+			violations["event_types"] = "event types must not be empty"
+		}
+	}
+	if len(sub.Secret) < minSecretLength {
+		violations["secret"] = fmt.Sprintf("secret must be at least %d characters", minSecretLength)
+	}
+	if len(violations) > 0 {
+		return errors.NewValidationError("invalid webhook subscription", violations)
+	}
+	return nil
+}
+
+func newSecret() string {
+	b := make([]byte, 32)
+	_, _ = rand.Read(b)
+	return "whsec_" + hex.EncodeToString(b)
+}
+
+func deliveryKey(subscriptionID, id string) string {
+	return subscriptionID + "/" + id
+}
@@ -0,0 +1,46 @@
+package notification
+
+// channelNames are the channel names used throughout the service
+var channelNames = map[Channel]string{
+	Channel_CHANNEL_EMAIL:  "email",
+	Channel_CHANNEL_SMS:    "sms",
+	Channel_CHANNEL_PUSH:   "push",
+	Channel_CHANNEL_IN_APP: "in_app",
+}
+
+// ChannelName returns the service's name for c, or "" when unspecified
+func ChannelName(c Channel) string {
+	return channelNames[c]
+}
+
+// ChannelFromName is the inverse of ChannelName
+func ChannelFromName(name string) Channel {
+	for c, n := range channelNames {
+		if n == name {
+			return c
+		}
+	}
+	return Channel_CHANNEL_UNSPECIFIED
+}
+
+// platformNames match the platform names used by the device registry
+var platformNames = map[Platform]string{
+	Platform_PLATFORM_ANDROID: "android",
+	Platform_PLATFORM_IOS:     "ios",
+	Platform_PLATFORM_WEB:     "web",
+}
+
+// PlatformName returns the registry's name for p, or "" when unspecified
+func PlatformName(p Platform) string {
+	return platformNames[p]
+}
+
+// PlatformFromName is the inverse of PlatformName
+func PlatformFromName(name string) Platform {
+	for p, n := range platformNames {
+		if n == name {
+			return p
+		}
+	}
+	return Platform_PLATFORM_UNSPECIFIED
+}
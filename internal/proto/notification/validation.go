@@ -0,0 +1,298 @@
+package notification
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"ride-sharing-notification/internal/pkg/errors"
+)
+
+// Validate methods are invoked by middleware.ValidationInterceptor before a
+// request reaches its handler. They live outside the generated files so
+// regenerating the protobuf code keeps them. Checks that need the service
+// packages, such as parsing quiet hours, are left to the handlers so this
+// package only depends on errors.
+
+func (r *RegisterEmailRequest) Validate() error {
+	return requireFields(map[string]string{
+		"to":  r.GetTo(),
+		"otp": r.GetOtp(),
+	})
+}
+
+func (r *ForgetPasswordEmailRequest) Validate() error {
+	return requireFields(map[string]string{
+		"to":  r.GetTo(),
+		"otp": r.GetOtp(),
+	})
+}
+
+func (r *GetEmailStatusRequest) Validate() error {
+	return requireFields(map[string]string{
+		"message_id": r.GetMessageId(),
+	})
+}
+
+func (r *PushRequest) Validate() error {
+	if err := requireFields(map[string]string{"title": r.GetTitle()}); err != nil {
+		return err
+	}
+	if (r.GetDeviceToken() == "") == (r.GetUserId() == "") {
+		return errors.NewValidationError("invalid request", map[string]string{
+			"device_token": "exactly one of device_token or user_id is required",
+		})
+	}
+	return nil
+}
+
+func (r *SMSRequest) Validate() error {
+	if err := requireFields(map[string]string{"to": r.GetTo()}); err != nil {
+		return err
+	}
+	if r.GetType() == "" && r.GetBody() == "" {
+		return errors.NewValidationError("invalid request", map[string]string{
+			"type": "type or body is required",
+		})
+	}
+	return nil
+}
+
+func (r *NotificationRequest) Validate() error {
+	violations := map[string]string{}
+	if r.GetType() == "" {
+		violations["type"] = "required"
+	}
+	recipient := r.GetRecipient()
+	if recipient.GetUserId() == "" && recipient.GetEmail() == "" && recipient.GetPhone() == "" && len(recipient.GetDeviceTokens()) == 0 {
+		violations["recipient"] = "a user ID or at least one contact point is required"
+	}
+	for _, channel := range r.GetChannels() {
+		if channel == Channel_CHANNEL_UNSPECIFIED {
+			violations["channels"] = "channel must be specified"
+		}
+	}
+	if tz := r.GetTimeZone(); tz != "" {
+		if _, err := time.LoadLocation(tz); err != nil {
+			violations["time_zone"] = "unknown time zone " + tz
+		}
+	}
+	if q := r.GetQuietHours(); q != nil && (q.GetStart() == "" || q.GetEnd() == "") {
+		violations["quiet_hours"] = "start and end are required"
+	}
+	if len(violations) > 0 {
+		return errors.NewValidationError("invalid request", violations)
+	}
+	return nil
+}
+
+func (r *UpdatePreferencesRequest) Validate() error {
+	if r.GetPreferences() == nil {
+		return errors.NewValidationError("invalid request", map[string]string{
+			"preferences": "required",
+		})
+	}
+	return nil
+}
+
+func (r *RegisterDeviceRequest) Validate() error {
+	if err := requireFields(map[string]string{
+		"token": r.GetToken(),
+	}); err != nil {
+		return err
+	}
+	if PlatformName(r.GetPlatform()) == "" {
+		return errors.NewValidationError("invalid request", map[string]string{
+			"platform": "platform must be specified",
+		})
+	}
+	return nil
+}
+
+func (r *UnregisterDeviceRequest) Validate() error {
+	return requireFields(map[string]string{
+		"token": r.GetToken(),
+	})
+}
+
+func (r *ScheduleNotificationRequest) Validate() error {
+	if r.GetNotification() == nil {
+		return errors.NewValidationError("invalid request", map[string]string{
+			"notification": "required",
+		})
+	}
+	if !r.GetSendAt().IsValid() {
+		return errors.NewValidationError("invalid request", map[string]string{
+			"send_at": "required",
+		})
+	}
+	return r.GetNotification().Validate()
+}
+
+func (r *CancelScheduledNotificationRequest) Validate() error {
+	return requireFields(map[string]string{
+		"id": r.GetId(),
+	})
+}
+
+func (r *RescheduleNotificationRequest) Validate() error {
+	if err := requireFields(map[string]string{"id": r.GetId()}); err != nil {
+		return err
+	}
+	if !r.GetSendAt().IsValid() {
+		return errors.NewValidationError("invalid request", map[string]string{
+			"send_at": "required",
+		})
+	}
+	return nil
+}
+
+func (r *SubscribeNotificationsRequest) Validate() error {
+	if r.GetCursor() == "" {
+		return nil
+	}
+	if _, err := strconv.ParseUint(r.GetCursor(), 10, 64); err != nil {
+		return errors.NewValidationError("invalid request", map[string]string{
+			"cursor": "must be the id of a received notification",
+		})
+	}
+	return nil
+}
+
+func (r *ListInboxRequest) Validate() error {
+	violations := map[string]string{}
+	if r.GetPage() < 0 {
+		violations["page"] = "must not be negative"
+	}
+	if r.GetPerPage() < 0 || r.GetPerPage() > 100 {
+		violations["per_page"] = "must be between 0 and 100"
+	}
+	if len(violations) > 0 {
+		return errors.NewValidationError("invalid request", violations)
+	}
+	return nil
+}
+
+func (r *UpdateInboxItemsRequest) Validate() error {
+	violations := map[string]string{}
+	if len(r.GetIds()) == 0 {
+		violations["ids"] = "required"
+	}
+	for _, id := range r.GetIds() {
+		if id == "" || strings.Contains(id, "/") {
+			violations["ids"] = "invalid inbox item id " + strconv.Quote(id)
+		}
+	}
+	if r.GetAction() == InboxAction_INBOX_ACTION_UNSPECIFIED {
+		violations["action"] = "action must be specified"
+	}
+	if len(violations) > 0 {
+		return errors.NewValidationError("invalid request", violations)
+	}
+	return nil
+}
+
+func (r *CreateWebhookRequest) Validate() error {
+	if err := requireFields(map[string]string{
+		"account_id": r.GetAccountId(),
+		"url":        r.GetUrl(),
+	}); err != nil {
+		return err
+	}
+	if len(r.GetEventTypes()) == 0 {
+		return errors.NewValidationError("invalid request", map[string]string{
+			"event_types": "required",
+		})
+	}
+	return nil
+}
+
+func (r *UpdateWebhookRequest) Validate() error {
+	return requireFields(map[string]string{
+		"id": r.GetId(),
+	})
+}
+
+func (r *DeleteWebhookRequest) Validate() error {
+	return requireFields(map[string]string{
+		"id": r.GetId(),
+	})
+}
+
+func (r *ListWebhookDeliveriesRequest) Validate() error {
+	violations := map[string]string{}
+	if r.GetSubscriptionId() == "" {
+		violations["subscription_id"] = "required"
+	}
+	switch r.GetStatus() {
+	case "", "pending", "delivered", "failed":
+	default:
+		violations["status"] = "must be pending, delivered or failed"
+	}
+	if r.GetPage() < 0 {
+		violations["page"] = "must not be negative"
+	}
+	if r.GetPerPage() < 0 || r.GetPerPage() > 100 {
+		violations["per_page"] = "must be between 0 and 100"
+	}
+	if len(violations) > 0 {
+		return errors.NewValidationError("invalid request", violations)
+	}
+	return nil
+}
+
+// The kind and reason of suppressions are checked against the known values
+// by the suppression list
+func (r *AddSuppressionRequest) Validate() error {
+	if err := requireFields(map[string]string{
+		"kind":   r.GetKind(),
+		"value":  r.GetValue(),
+		"reason": r.GetReason(),
+	}); err != nil {
+		return err
+	}
+	if r.GetExpiresAt() != nil && !r.GetExpiresAt().AsTime().After(time.Now()) {
+		return errors.NewValidationError("invalid request", map[string]string{
+			"expires_at": "must be in the future",
+		})
+	}
+	return nil
+}
+
+func (r *RemoveSuppressionRequest) Validate() error {
+	return requireFields(map[string]string{
+		"kind":  r.GetKind(),
+		"value": r.GetValue(),
+	})
+}
+
+func (r *ListSuppressionsRequest) Validate() error {
+	violations := map[string]string{}
+	if r.GetValue() != "" && r.GetKind() == "" {
+		violations["kind"] = "required with value"
+	}
+	if r.GetPage() < 0 {
+		violations["page"] = "must not be negative"
+	}
+	if r.GetPerPage() < 0 || r.GetPerPage() > 100 {
+		violations["per_page"] = "must be between 0 and 100"
+	}
+	if len(violations) > 0 {
+		return errors.NewValidationError("invalid request", violations)
+	}
+	return nil
+}
+
+// requireFields reports every empty field as a validation error
+func requireFields(fields map[string]string) error {
+	missing := map[string]string{}
+	for name, value := range fields {
+		if value == "" {
+			missing[name] = "required"
+		}
+	}
+	if len(missing) > 0 {
+		return errors.NewValidationError("invalid request", missing)
+	}
+	return nil
+}